@@ -0,0 +1,179 @@
+package khadijah
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Converter turns a value into another. It is used to turn a field's value
+// into a param and a record's value into a field's value
+type Converter func(value interface{}) (interface{}, error)
+
+// Converters is a registry of Converters keyed by the type that they handle.
+// When a field's type isn't registered the built-ins are used, in order:
+//
+//	time.Time and time.Duration are used as is
+//	driver.Valuer uses Value()
+//	encoding.TextMarshaler uses MarshalText()
+//	fmt.Stringer uses String()
+//
+// Pointers are dereferenced and nil pointers become nil. When hydrating,
+// sql.Scanner and encoding.TextUnmarshaler are the built-ins
+type Converters struct {
+	params    map[reflect.Type]Converter
+	hydrators map[reflect.Type]Converter
+}
+
+// NewConverters creates an empty Converters registry
+func NewConverters() *Converters {
+	return &Converters{
+		params:    map[reflect.Type]Converter{},
+		hydrators: map[reflect.Type]Converter{},
+	}
+}
+
+// Register sets the Converter used to turn values of the type into params
+func (c *Converters) Register(valueType reflect.Type, convert Converter) {
+	c.params[valueType] = convert
+}
+
+// RegisterHydrator sets the Converter used to turn record values into values
+// of the type. The Converter's result must be assignable to the type
+func (c *Converters) RegisterHydrator(valueType reflect.Type, convert Converter) {
+	c.hydrators[valueType] = convert
+}
+
+func (c *Converters) param(valueType reflect.Type) (Converter, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	convert, ok := c.params[valueType]
+
+	return convert, ok
+}
+
+func (c *Converters) hydrator(valueType reflect.Type) (Converter, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	convert, ok := c.hydrators[valueType]
+
+	return convert, ok
+}
+
+// convert returns the param for the value. ok is false when no converter
+// handled the value. Pointers are dereferenced first so that a pointer is
+// converted like the value it points to
+func (c *Converters) convert(value reflect.Value) (converted interface{}, ok bool, err error) {
+	var pointer reflect.Value
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, false, nil
+		}
+
+		if value.Kind() == reflect.Ptr {
+			pointer = value
+		}
+
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return nil, false, nil
+	}
+
+	if convert, ok := c.param(value.Type()); ok {
+		converted, err := convert(value.Interface())
+
+		return converted, true, err
+	}
+
+	if value.Type() == timeType {
+		return value.Interface(), true, nil
+	}
+
+	if value.Type() == durationType {
+		return value.Int(), true, nil
+	}
+
+	if converted, ok, err := convertInterface(value); ok {
+		return converted, ok, err
+	}
+
+	// methods with pointer receivers are only found on the pointer
+	if pointer.IsValid() {
+		return convertInterface(pointer)
+	}
+
+	return nil, false, nil
+}
+
+// convertInterface converts the value with the methods of the built-in interfaces
+func convertInterface(value reflect.Value) (converted interface{}, ok bool, err error) {
+	switch v := value.Interface().(type) {
+	case driver.Valuer:
+		converted, err := v.Value()
+
+		return converted, true, err
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+
+		return string(text), true, err
+	case fmt.Stringer:
+		return v.String(), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// hydrate sets the dest with the value using the registered hydrator or the
+// built-ins. handled is false when none of them can be used
+func (c *Converters) hydrate(dest reflect.Value, value interface{}) (handled bool, err error) {
+	if convert, ok := c.hydrator(dest.Type()); ok {
+		converted, err := convert(value)
+		if err != nil {
+			return true, err
+		}
+
+		if converted == nil {
+			dest.Set(reflect.Zero(dest.Type()))
+			return true, nil
+		}
+
+		convertedValue := reflect.ValueOf(converted)
+		if !convertedValue.Type().AssignableTo(dest.Type()) {
+			return true, fmt.Errorf(`%w: %T into %s`, ErrCannotHydrate, converted, dest.Type())
+		}
+
+		dest.Set(convertedValue)
+		return true, nil
+	}
+
+	if dest.Kind() == reflect.Ptr || !dest.CanAddr() || dest.Type() == timeType {
+		return false, nil
+	}
+
+	if reflect.TypeOf(value).AssignableTo(dest.Type()) {
+		return false, nil
+	}
+
+	switch d := dest.Addr().Interface().(type) {
+	case sql.Scanner:
+		return true, d.Scan(value)
+	case encoding.TextUnmarshaler:
+		if text, ok := value.(string); ok {
+			return true, d.UnmarshalText([]byte(text))
+		}
+	}
+
+	return false, nil
+}
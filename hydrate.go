@@ -0,0 +1,370 @@
+package khadijah
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Propertied is anything that holds a map of properties, the node and
+// relationship types returned by the neo4j driver are Propertied
+type Propertied interface {
+	GetProperties() map[string]interface{}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Hydrate fills the struct that dest points to with the values found in the
+// record. The record can be an M, a map[string]interface{}, or a Propertied
+// value like a node or relationship. The same tag rules used in Parse are used
+// to match the properties to the fields. Properties that are not in the
+// record leave the field untouched
+func (m *Maxine) Hydrate(record interface{}, dest interface{}) error {
+	props, err := recordProperties(record)
+	if err != nil {
+		return err
+	}
+
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		return fmt.Errorf(`%w: got %T`, ErrNotPointer, dest)
+	}
+
+	destValue = destValue.Elem()
+	for destValue.Kind() == reflect.Ptr {
+		if destValue.IsNil() {
+			destValue.Set(reflect.New(destValue.Type().Elem()))
+		}
+
+		destValue = destValue.Elem()
+	}
+
+	if destValue.Kind() != reflect.Struct {
+		return fmt.Errorf(`%w: got %s`, ErrNotStruct, destValue.Kind())
+	}
+
+	return m.hydrateStruct(props, destValue)
+}
+
+func (m *Maxine) hydrateStruct(props map[string]interface{}, destValue reflect.Value) error {
+	return m.hydrateFields(props, destValue, "")
+}
+
+// hydrateFields fills the struct's fields with the props whose names are
+// prefixed with prefix, which is how flattened structs are hydrated
+func (m *Maxine) hydrateFields(props map[string]interface{}, destValue reflect.Value, prefix string) error {
+	for _, field := range m.taggedFields(destValue.Type()) {
+		if !field.IsExported() {
+			continue
+		}
+
+		name := prefix + field.name
+		mode := StructValue
+
+		if isStructType(field.Type) {
+			mode = m.structMode(field.opts)
+		}
+
+		if mode == StructSkip {
+			continue
+		}
+
+		if mode == StructFlatten {
+			if !hasPrefixedProperty(props, name+"_") {
+				continue
+			}
+
+			fieldValue, err := fieldByIndexAlloc(destValue, field.Index)
+			if err != nil {
+				return err
+			}
+
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+				}
+
+				fieldValue = fieldValue.Elem()
+			}
+
+			if err := m.hydrateFields(props, fieldValue, name+"_"); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		value, ok := props[name]
+		if !ok {
+			continue
+		}
+
+		fieldValue, err := fieldByIndexAlloc(destValue, field.Index)
+		if err != nil {
+			return err
+		}
+
+		if encoded, isString := value.(string); mode == StructSerialize && isString {
+			if err := json.Unmarshal([]byte(encoded), fieldValue.Addr().Interface()); err != nil {
+				return fmt.Errorf(`%s: %w`, name, err)
+			}
+
+			continue
+		}
+
+		if err := m.hydrateValue(fieldValue, value, field.opts.Contains(OptionString)); err != nil {
+			return fmt.Errorf(`%s: %w`, name, err)
+		}
+	}
+
+	return nil
+}
+
+// hasPrefixedProperty reports if any of the props' names start with prefix
+func hasPrefixedProperty(props map[string]interface{}, prefix string) bool {
+	for name := range props {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hydrateValue converts the value so that it can be set on dest
+func (m *Maxine) hydrateValue(dest reflect.Value, value interface{}, fromString bool) error {
+	if value == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+
+	if handled, err := m.Converters.hydrate(dest, value); handled {
+		return err
+	}
+
+	if dest.Kind() == reflect.Ptr {
+		elem := reflect.New(dest.Type().Elem())
+		if err := m.hydrateValue(elem.Elem(), value, fromString); err != nil {
+			return err
+		}
+
+		dest.Set(elem)
+		return nil
+	}
+
+	source := reflect.ValueOf(value)
+
+	if fromString && source.Kind() == reflect.String && dest.Kind() != reflect.String {
+		return hydrateString(dest, source.String())
+	}
+
+	if source.Type().AssignableTo(dest.Type()) {
+		dest.Set(source)
+		return nil
+	}
+
+	if dest.Type() == timeType {
+		return hydrateTime(dest, source)
+	}
+
+	switch dest.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !dest.OverflowInt(source.Int()) {
+				dest.SetInt(source.Int())
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if source.Uint() <= uint64(1<<63-1) && !dest.OverflowInt(int64(source.Uint())) {
+				dest.SetInt(int64(source.Uint()))
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			f := source.Float()
+			if f == float64(int64(f)) && !dest.OverflowInt(int64(f)) {
+				dest.SetInt(int64(f))
+				return nil
+			}
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if source.Int() >= 0 && !dest.OverflowUint(uint64(source.Int())) {
+				dest.SetUint(uint64(source.Int()))
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !dest.OverflowUint(source.Uint()) {
+				dest.SetUint(source.Uint())
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			f := source.Float()
+			if f >= 0 && f == float64(uint64(f)) && !dest.OverflowUint(uint64(f)) {
+				dest.SetUint(uint64(f))
+				return nil
+			}
+		}
+
+	case reflect.Float32, reflect.Float64:
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dest.SetFloat(float64(source.Int()))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			dest.SetFloat(float64(source.Uint()))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			if !dest.OverflowFloat(source.Float()) {
+				dest.SetFloat(source.Float())
+				return nil
+			}
+		}
+
+	case reflect.String, reflect.Bool:
+		if source.Kind() == dest.Kind() {
+			dest.Set(source.Convert(dest.Type()))
+			return nil
+		}
+
+	case reflect.Slice:
+		if source.Kind() == reflect.Slice || source.Kind() == reflect.Array {
+			items := reflect.MakeSlice(dest.Type(), source.Len(), source.Len())
+
+			for i := 0; i < source.Len(); i++ {
+				if err := m.hydrateValue(items.Index(i), source.Index(i).Interface(), fromString); err != nil {
+					return fmt.Errorf(`[%d]: %w`, i, err)
+				}
+			}
+
+			dest.Set(items)
+			return nil
+		}
+
+	case reflect.Map:
+		if source.Kind() == reflect.Map && dest.Type().Key().Kind() == reflect.String && source.Type().Key().Kind() == reflect.String {
+			items := reflect.MakeMapWithSize(dest.Type(), source.Len())
+			iter := source.MapRange()
+
+			for iter.Next() {
+				item := reflect.New(dest.Type().Elem()).Elem()
+				if err := m.hydrateValue(item, iter.Value().Interface(), fromString); err != nil {
+					return fmt.Errorf(`%s: %w`, iter.Key().String(), err)
+				}
+
+				items.SetMapIndex(iter.Key().Convert(dest.Type().Key()), item)
+			}
+
+			dest.Set(items)
+			return nil
+		}
+
+	case reflect.Struct:
+		if props, err := recordProperties(value); err == nil {
+			return m.hydrateStruct(props, dest)
+		}
+	}
+
+	return fmt.Errorf(`%w: cannot use %T as %s`, ErrCannotHydrate, value, dest.Type())
+}
+
+// hydrateTime sets a time.Time from a time value, a type that is based on
+// time.Time, or an RFC 3339 string
+func hydrateTime(dest, source reflect.Value) error {
+	if source.Type().ConvertibleTo(timeType) {
+		dest.Set(source.Convert(timeType))
+		return nil
+	}
+
+	if source.Kind() == reflect.String {
+		parsed, err := time.Parse(time.RFC3339Nano, source.String())
+		if err != nil {
+			return fmt.Errorf(`%w: %v`, ErrCannotHydrate, err)
+		}
+
+		dest.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	return fmt.Errorf(`%w: cannot use %s as %s`, ErrCannotHydrate, source.Type(), dest.Type())
+}
+
+// hydrateString parses the string into a scalar value for fields that use
+// the string tag option
+func hydrateString(dest reflect.Value, value string) error {
+	var err error
+
+	switch dest.Kind() {
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(value); err == nil {
+			dest.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(value, 10, dest.Type().Bits()); err == nil {
+			dest.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if u, err = strconv.ParseUint(value, 10, dest.Type().Bits()); err == nil {
+			dest.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(value, dest.Type().Bits()); err == nil {
+			dest.SetFloat(f)
+		}
+	default:
+		err = fmt.Errorf(`cannot use string as %s`, dest.Type())
+	}
+
+	if err != nil {
+		return fmt.Errorf(`%w: %v`, ErrCannotHydrate, err)
+	}
+
+	return nil
+}
+
+// recordProperties pulls the property map out of a record
+func recordProperties(record interface{}) (map[string]interface{}, error) {
+	switch rec := record.(type) {
+	case M:
+		return rec, nil
+	case map[string]interface{}:
+		return rec, nil
+	case Propertied:
+		return rec.GetProperties(), nil
+	case nil:
+		return nil, ErrNilEntity
+	}
+
+	return nil, fmt.Errorf(`%w: got %T`, ErrNotRecord, record)
+}
+
+// fieldByIndexAlloc works like reflect.Value.FieldByIndex, but allocates any
+// nil embedded struct pointers along the way
+func fieldByIndexAlloc(value reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !value.CanSet() {
+					return reflect.Value{}, fmt.Errorf(`%w: cannot set embedded pointer to unexported struct %s`, ErrCannotHydrate, value.Type().Elem())
+				}
+
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+
+			value = value.Elem()
+		}
+
+		value = value.Field(x)
+	}
+
+	return value, nil
+}
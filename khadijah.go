@@ -1,11 +1,19 @@
 package khadijah
 
+import "reflect"
+
 var (
 	DefaultTagName       = "json"
 	DefaultVariable      = "flava"
 	DefaultStartVariable = "start"
 	DefaultEndVariable   = "end"
 	DefaultMatchClause   = M{"id(+v+)": "id"}
+	RowsParam            = "rows"
+	RowVariable          = "row"
+	PathVariable         = "path"
+	SoftDeleteParam      = "now"
+	PropsParam           = "props"
+	OnMatchPropsParam    = "onMatchProps"
 	DefaultSettings      = []KhadijahSetting{
 		SetTagName(DefaultTagName),
 		SetVariable(DefaultVariable),
@@ -36,6 +44,15 @@ func SetVariable(variable string) KhadijahSetting {
 func SetMatchClause(matchClause M) KhadijahSetting {
 	return func(instance *Khadijah) {
 		instance.MatchClause = matchClause
+		instance.OrderedMatchClause = nil
+	}
+}
+
+// SetOrderedMatchClause will set Khadijah.OrderedMatchClause, it is used in
+// place of Khadijah.MatchClause and keeps the order of its conditions
+func SetOrderedMatchClause(matchClause OM) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.OrderedMatchClause = matchClause
 	}
 }
 
@@ -73,6 +90,88 @@ func SetParamPrefix(paramPrefix string) KhadijahSetting {
 	}
 }
 
+// SetAllowUnboundedDelete will set Khadijah.AllowUnboundedDelete. When true, edge
+// deletes without any match clause, which remove every edge with the label, are allowed
+func SetAllowUnboundedDelete(allow bool) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.AllowUnboundedDelete = allow
+	}
+}
+
+// SetStrictIdentifiers will set Khadijah.StrictIdentifiers. When true, labels,
+// property names, and params that would need to be quoted are rejected with
+// ErrUnsafeIdentifier instead of being quoted
+func SetStrictIdentifiers(strict bool) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.StrictIdentifiers = strict
+	}
+}
+
+// SetStructMode will set Khadijah.StructMode, which defines how struct typed
+// fields are turned into properties. A field can override it with the flatten
+// or serialize tag options
+func SetStructMode(mode StructMode) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.StructMode = mode
+	}
+}
+
+// SetSoftDelete will set Khadijah.SoftDeleteProperty. When it is set, the delete
+// functions SET the property to $now in place of DELETE and the functions
+// that match nodes and edges skip the ones that have it
+//		MATCH (x) WHERE id(x) = $id AND x.deleted_at IS NULL SET x.deleted_at = $now
+func SetSoftDelete(property string) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.SoftDeleteProperty = property
+	}
+}
+
+// SetSoftDeleteLabel will set Khadijah.SoftDeleteLabel, the label that is added
+// to soft deleted nodes
+func SetSoftDeleteLabel(label string) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.SoftDeleteLabel = label
+	}
+}
+
+// SetNullPolicy will set Khadijah.NullPolicy, it defines how nil valued
+// properties are handled when updating. NullSet is the default
+func SetNullPolicy(policy NullPolicy) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.NullPolicy = policy
+	}
+}
+
+// SetMapProperties will set Khadijah.MapProperties. When true, creates and
+// updates pass the properties as a single $props map param, which keeps the
+// query the same no matter which fields are set
+//		CREATE (x:Label) SET x = $props
+//		MATCH (x:Label) WHERE id(x) = $id SET x += $props
+func SetMapProperties(mapProperties bool) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.MapProperties = mapProperties
+	}
+}
+
+// SetNormalize will set Khadijah.Normalize. When true, the same entity type
+// and operation always build the same query: properties are passed in map
+// params, as they are with SetMapProperties, and match clause conditions are
+// sorted. This keeps the server's plan cache from filling up
+func SetNormalize(normalize bool) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.Normalize = normalize
+	}
+}
+
+// SetClock will set Khadijah.Clock, it provides the time for the autoCreate
+// and autoUpdate timestamps and soft deletes. Without one, timestamps are set
+// with datetime()
+func SetClock(clock Clock) KhadijahSetting {
+	return func(instance *Khadijah) {
+		instance.Clock = clock
+	}
+}
+
 // New creates an instance of Khadijah with "json" as the default tag name
 // used to pull values from the passed in structs and "flava" as the default
 // variable that is used in the returned queries
@@ -81,38 +180,100 @@ func New(settings ...KhadijahSetting) *Khadijah {
 	settings = append(DefaultSettings, settings...)
 	instance := &Khadijah{}
 	instance.Apply(settings...)
-	instance.RootMaxx = NewMaxine(instance.TagName, instance.Variable, instance.ParamPrefix, instance.MatchClause)
 
 	return instance
 }
 
 type Khadijah struct {
-	TagName       string
-	Variable      string
-	StartVariable string
-	EndVariable   string
-	MatchClause   M
-	ParamPrefix   string
-	RootMaxx      *Maxine
+	TagName              string
+	Variable             string
+	StartVariable        string
+	EndVariable          string
+	MatchClause          M
+	OrderedMatchClause   OM
+	ParamPrefix          string
+	AllowUnboundedDelete bool
+	StrictIdentifiers    bool
+	StructMode           StructMode
+	NullPolicy           NullPolicy
+	MapProperties        bool
+	Normalize            bool
+	SoftDeleteProperty   string
+	SoftDeleteLabel      string
+	IncludeSoftDeleted   bool
+	Clock                Clock
+	Converters           *Converters
+	RootMaxx             *Maxine
 }
 
-// Apply will set some properties on the instance
+// Apply will set some properties on the instance and rebuild the RootMaxx
+// with them
 func (k *Khadijah) Apply(settings ...KhadijahSetting) {
+	if k.Converters == nil {
+		k.Converters = NewConverters()
+	}
+
 	for _, setFn := range settings {
 		setFn(k)
 	}
+
+	k.RootMaxx = NewMaxine(k.TagName, k.Variable, k.ParamPrefix, k.matchClause())
+	k.RootMaxx.StrictIdentifiers = k.StrictIdentifiers
+	k.RootMaxx.StructMode = k.StructMode
+	k.RootMaxx.NullPolicy = k.NullPolicy
+	k.RootMaxx.MapProperties = k.MapProperties
+	k.RootMaxx.Normalize = k.Normalize
+	k.RootMaxx.Converters = k.Converters
+	k.RootMaxx.SoftDeleteProperty = k.SoftDeleteProperty
+	k.RootMaxx.SoftDeleteLabel = k.SoftDeleteLabel
+	k.RootMaxx.IncludeDeleted = k.IncludeSoftDeleted
+	k.RootMaxx.Clock = k.Clock
+}
+
+// matchClause returns the default match clause, the OrderedMatchClause when
+// it is set
+func (k *Khadijah) matchClause() Clause {
+	if k.OrderedMatchClause != nil {
+		return k.OrderedMatchClause
+	}
+
+	return k.MatchClause
+}
+
+// IncludeDeleted returns a copy of the instance whose queries include the soft
+// deleted nodes and edges
+//		instance.IncludeDeleted().MatchNode(user, &label, true)
+func (k *Khadijah) IncludeDeleted() *Khadijah {
+	clone := *k
+	clone.Apply(func(instance *Khadijah) {
+		instance.IncludeSoftDeleted = true
+	})
+
+	return &clone
+}
+
+// RegisterConverter sets the Converter used to turn field values of the type
+// into params. It takes precedence over the built-in conversions
+func (k *Khadijah) RegisterConverter(valueType reflect.Type, convert Converter) {
+	k.Converters.Register(valueType, convert)
+}
+
+// RegisterHydrator sets the Converter used to turn record values into field
+// values of the type when hydrating
+func (k *Khadijah) RegisterHydrator(valueType reflect.Type, convert Converter) {
+	k.Converters.RegisterHydrator(valueType, convert)
 }
 
 // NodeWithProperties creates a simple (var:label {propts}) string
 func (k *Khadijah) NodeWithProperties(entity interface{}, label *string) *Maxine {
-	reg := newRegine(k.MatchClause, k.RootMaxx)
+	reg := newRegine(k.matchClause(), k.RootMaxx)
 
 	return reg.nodeWithProperties(entity, label)
 }
 
 // MatchNode creates a simple Match (var:label {props}) cypther query
 func (k *Khadijah) MatchNode(entity interface{}, label *string, withReturn bool) *Maxine {
-	reg := newRegine(k.MatchClause, k.RootMaxx)
+	reg := newRegine(k.matchClause(), k.RootMaxx)
 
 	return reg.matchNode(entity, label, withReturn)
 }
@@ -120,30 +281,86 @@ func (k *Khadijah) MatchNode(entity interface{}, label *string, withReturn bool)
 // CreateNode builds a simple cypher CREATE query that looks like:
 //     CREATE (x:Label {param: $param}) RETURN x
 func (k *Khadijah) CreateNode(entity interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
-	reg := newRegine(k.MatchClause, k.RootMaxx)
+	reg := newRegine(k.matchClause(), k.RootMaxx)
 
 	return reg.createNode(entity, label, withReturn, excludes...)
 }
 
-// UpdateNodeWithMatch builds a simpole cyper Merge ... SET query that looks like:
-//		MERGE (x:Label {param: $param}) SET param1 = $param1 RETURN x
-func (k *Khadijah) UpdateNodeWithMatch(entity interface{}, label *string, matchClause M, withReturn bool, excludes ...string) *Maxine {
-	reg := newRegine(k.MatchClause, k.RootMaxx)
+// UpdateNodeWithMatch builds a simpole cyper MATCH ... SET query that looks like:
+//		MATCH (x:Label) WHERE x.param = $param SET x.param1 = $param1 RETURN x
+func (k *Khadijah) UpdateNodeWithMatch(entity interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
 
 	return reg.updateNodeWithMatch(entity, label, matchClause, withReturn, excludes...)
 }
 
 // UpdateNode works like UpdateNodeWithMatch, but defaults the matchClause to {id: $id}
 // creates a query that looks like:
-//		MATCH (x:Label {id: $id}) SET param1 = $param1 RETURN x
+//		MATCH (x:Label) WHERE id(x) = $id SET x.param1 = $param1 RETURN x
 func (k *Khadijah) UpdateNode(entity interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
-	return k.UpdateNodeWithMatch(entity, label, k.MatchClause, withReturn, excludes...)
+	return k.UpdateNodeWithMatch(entity, label, k.matchClause(), withReturn, excludes...)
+}
+
+// RemovePropertiesWithMatch builds a cypher MATCH ... REMOVE query that
+// removes the props from the matched node
+//		MATCH (x:Label) WHERE x.param = $param REMOVE x.prop1, x.prop2
+func (k *Khadijah) RemovePropertiesWithMatch(entity interface{}, label *string, matchClause Clause, props ...string) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.removePropertiesWithMatch(entity, label, matchClause, props...)
+}
+
+// RemoveProperties works like RemovePropertiesWithMatch, but defaults the matchClause to {id: $id}
+//		MATCH (x:Label) WHERE id(x) = $id REMOVE x.prop1, x.prop2
+func (k *Khadijah) RemoveProperties(entity interface{}, label *string, props ...string) *Maxine {
+	return k.RemovePropertiesWithMatch(entity, label, k.matchClause(), props...)
+}
+
+// UpdateNodeDiffWithMatch builds a cypher MATCH ... SET ... REMOVE query that only
+// SETs the properties that changed between before and after. The properties
+// that after leaves out, because of omitempty for example, or that became nil are REMOVEd
+//		MATCH (x:Label) WHERE x.param = $param SET x.changed = $changed REMOVE x.removed RETURN x
+func (k *Khadijah) UpdateNodeDiffWithMatch(before, after interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.updateNodeDiffWithMatch(before, after, label, matchClause, withReturn, excludes...)
+}
+
+// UpdateNodeDiff works like UpdateNodeDiffWithMatch, but defaults the matchClause to {id: $id}
+//		MATCH (x:Label) WHERE id(x) = $id SET x.changed = $changed REMOVE x.removed RETURN x
+func (k *Khadijah) UpdateNodeDiff(before, after interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
+	return k.UpdateNodeDiffWithMatch(before, after, label, k.matchClause(), withReturn, excludes...)
+}
+
+// UpsertNode builds a cypher MERGE query that matches on the keyFields and
+// sets the rest of the properties. Properties tagged as readonly are only set
+// when the node is created. If keyFields is empty, the properties tagged as key are used
+//		MERGE (x:Label {id: $id}) ON CREATE SET x.param1 = $param1, x.created = $created ON MATCH SET x.param1 = $param1 RETURN x
+func (k *Khadijah) UpsertNode(entity interface{}, label *string, keyFields []string, withReturn bool, excludes ...string) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.upsertNode(entity, label, keyFields, withReturn, excludes...)
+}
+
+// UpdateLabelsWithMatch builds a cypher MATCH ... SET ... REMOVE query that adds
+// and removes labels from the matched node
+//		MATCH (x:Label) WHERE x.param = $param SET x:Active REMOVE x:Inactive RETURN x
+func (k *Khadijah) UpdateLabelsWithMatch(entity interface{}, label *string, matchClause Clause, add, remove []string, withReturn bool) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.updateLabelsWithMatch(entity, label, matchClause, add, remove, withReturn)
+}
+
+// UpdateLabels works like UpdateLabelsWithMatch, but defaults the matchClause to {id: $id}
+//		MATCH (x:Label) WHERE id(x) = $id SET x:Active REMOVE x:Inactive RETURN x
+func (k *Khadijah) UpdateLabels(entity interface{}, label *string, add, remove []string, withReturn bool) *Maxine {
+	return k.UpdateLabelsWithMatch(entity, label, k.matchClause(), add, remove, withReturn)
 }
 
 // DeleteNodeWithMatch builds a cypher MATCH .. DELETE quer that looks like:
 //		MATCH (x {param: $param}) [DETACH] DELETE x
-func (k *Khadijah) DeleteNodeWithMatch(entity interface{}, detach bool, matchClause M) *Maxine {
-	reg := newRegine(k.MatchClause, k.RootMaxx)
+func (k *Khadijah) DeleteNodeWithMatch(entity interface{}, detach bool, matchClause Clause) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
 
 	return reg.deleteNodeWithMatch(entity, detach, matchClause)
 }
@@ -151,7 +368,7 @@ func (k *Khadijah) DeleteNodeWithMatch(entity interface{}, detach bool, matchCla
 // DetachDeleteNodeWithMatch build a MATCH ... DETACH DELETE cypher query using
 // the provided matching clause
 //		MATCH (x {param: $param}) [DETACH] DELETE x
-func (k *Khadijah) DetachDeleteNodeWithMatch(entity interface{}, matchClause M) *Maxine {
+func (k *Khadijah) DetachDeleteNodeWithMatch(entity interface{}, matchClause Clause) *Maxine {
 	return k.DeleteNodeWithMatch(entity, true, matchClause)
 }
 
@@ -159,14 +376,14 @@ func (k *Khadijah) DetachDeleteNodeWithMatch(entity interface{}, matchClause M)
 // matching clause
 //		MATCH (x {param: $param}) [DETACH] DELETE x
 func (k *Khadijah) DetachDeleteNode(entity interface{}) *Maxine {
-	return k.DeleteNodeWithMatch(entity, true, k.MatchClause)
+	return k.DeleteNodeWithMatch(entity, true, k.matchClause())
 }
 
 // DeleteNode build a MATCH ... [DETACH] DELETE cypher query using the default
 // matching clause
 //		MATCH (x {param: $param}) [DETACH] DELETE x
 func (k *Khadijah) DeleteNode(entity interface{}, detach bool) *Maxine {
-	return k.DeleteNodeWithMatch(entity, detach, k.MatchClause)
+	return k.DeleteNodeWithMatch(entity, detach, k.matchClause())
 }
 
 // CreateEdge builds a complex MATCh (nodeA), (nodeB) CREATE query
@@ -177,30 +394,456 @@ func (k *Khadijah) CreateEdge(start, end, edge interface{}, direction string, st
 
 // CreateEdgeWithMatches a complex MATCh (nodeA), (nodeB) CREATE query
 //		MATCH (start:Lable {matches}), (end:Label {props}) CREATE (start)-[edge:label {matches}]->(end) RETURN start, end, edge
-func (k *Khadijah) CreateEdgeWithMatches(start interface{}, startLabel *string, startMatchClause M, direction string, end interface{}, endLabel *string, endMatchClause M, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) *Maxine {
-	syn := newSynclaire(k.MatchClause, k.StartVariable, k.EndVariable, k.RootMaxx)
+func (k *Khadijah) CreateEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
 
 	return syn.createEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, withReturn, excldues...)
 }
 
-func (k *Khadijah) UpdateEdgeWithMatches(start interface{}, startLabel *string, startMatchClause M, direction string, end interface{}, endLabel *string, endMatchClause M, edge interface{}, edgeLabel *string, edgeMatchClause M, withReturn bool, excldues ...string) *Maxine {
-	syn := newSynclaire(k.MatchClause, k.StartVariable, k.EndVariable, k.RootMaxx)
+// UpdateEdgeWithMatches builds a query that matches both nodes with their own
+// match clauses, then matches the edge between them with the edgeMatchClause
+// and sets its properties. When the edgeMatchClause is empty, every edge of
+// that label between the two nodes is updated
+//		MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MATCH (start)-[edge:label]->(end) WHERE id(edge) = $id SET edge.param = $param RETURN start, edge, end
+func (k *Khadijah) UpdateEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, edgeMatchClause Clause, withReturn bool, excldues ...string) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
 
 	return syn.updateEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, edgeMatchClause, withReturn, excldues...)
 }
 
+// UpdateEdge works like UpdateEdgeWithMatches, but uses the default match clause
+// for the nodes and the edge
 func (k *Khadijah) UpdateEdge(start interface{}, startLabel *string, direction string, end interface{}, endLabel *string, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) *Maxine {
 	return k.UpdateEdgeWithMatches(start, startLabel, DefaultMatchClause, direction, end, endLabel, DefaultMatchClause, edge, edgeLabel, DefaultMatchClause, withReturn, excldues...)
 }
 
-func (k *Khadijah) DeleteEdgeWithMatchingLabels(startLabel, direction, endLabel string, edge interface{}, edgeLabel string, edgeMatchClause M) *Maxine {
-	syn := newSynclaire(k.MatchClause, k.StartVariable, k.EndVariable, k.RootMaxx)
+// UpsertEdgeWithMatches builds a query that matches both nodes with their own
+// match clauses, then merges the edge between them on its key properties.
+// readonly properties are only set when the edge is created
+//		MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MERGE (start)-[edge:label {key: $key}]->(end) ON CREATE SET edge.param = $param ON MATCH SET edge.param = $param RETURN start, edge, end
+func (k *Khadijah) UpsertEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, keyFields []string, withReturn bool, excldues ...string) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.upsertEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, keyFields, withReturn, excldues...)
+}
+
+// UpsertEdge works like UpsertEdgeWithMatches, but uses the default match clause
+// for the nodes
+func (k *Khadijah) UpsertEdge(start interface{}, startLabel *string, direction string, end interface{}, endLabel *string, edge interface{}, edgeLabel *string, keyFields []string, withReturn bool, excldues ...string) *Maxine {
+	return k.UpsertEdgeWithMatches(start, startLabel, DefaultMatchClause, direction, end, endLabel, DefaultMatchClause, edge, edgeLabel, keyFields, withReturn, excldues...)
+}
+
+// DeleteEdgeWithMatchingLabels builds a MATCH ... DELETE query for the edges
+// between nodes with the given labels. The edgeMatchClause is required unless
+// unbounded deletes were allowed
+//		MATCH (:StartLabel)-[edge:label]->(:EndLabel) WHERE edge.param = $param DELETE edge
+func (k *Khadijah) DeleteEdgeWithMatchingLabels(startLabel, direction, endLabel string, edge interface{}, edgeLabel string, edgeMatchClause Clause) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
 
 	return syn.deleteEdgeWithMatchingLabels(startLabel, direction, endLabel, edge, edgeLabel, edgeMatchClause)
 }
 
-func (k *Khadijah) DeleteEdge(edge interface{}, edgeLabel, direction string, edgeMatchClause M) *Maxine {
-	syn := newSynclaire(k.MatchClause, k.StartVariable, k.EndVariable, k.RootMaxx)
+// DeleteEdge builds a MATCH ... DELETE query for the edges that match the
+// edgeMatchClause. The edgeMatchClause is required unless unbounded deletes were allowed
+//		MATCH ()-[edge:label]->() WHERE edge.param = $param DELETE edge
+func (k *Khadijah) DeleteEdge(edge interface{}, edgeLabel, direction string, edgeMatchClause Clause) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
 
 	return syn.deleteEdge(edge, edgeLabel, direction, edgeMatchClause)
 }
+
+// DeleteEdgeWithMatches builds a query that matches both nodes with their own
+// match clauses and deletes the edges between them that match the optional
+// edgeMatchClause. The edge entity can be nil when there isn't an edgeMatchClause
+//		MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MATCH (start)-[edge:label]->(end) WHERE edge.param = $param DELETE edge
+func (k *Khadijah) DeleteEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, edgeMatchClause Clause) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.deleteEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, edgeMatchClause)
+}
+
+// DeleteEdgeBetween works like DeleteEdgeWithMatches, but uses the default match
+// clause for the nodes and deletes every edge with the label between them
+//		MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MATCH (start)-[edge:label]->(end) DELETE edge
+func (k *Khadijah) DeleteEdgeBetween(start interface{}, startLabel *string, direction string, end interface{}, endLabel *string, edgeLabel string) *Maxine {
+	return k.DeleteEdgeWithMatches(start, startLabel, DefaultMatchClause, direction, end, endLabel, DefaultMatchClause, nil, &edgeLabel, nil)
+}
+
+// CreateNodes builds a single UNWIND ... CREATE query for a slice of entities
+// each entity becomes a row in the rows param
+//		UNWIND $rows AS row CREATE (x:Label) SET x = row RETURN x
+func (k *Khadijah) CreateNodes(entities interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.createNodes(entities, label, withReturn, excludes...)
+}
+
+// UpdateNodesWithMatch builds a single UNWIND ... MATCH ... SET query for a slice
+// of entities. Each row holds the match params and the props to be set
+//		UNWIND $rows AS row MATCH (x:Label) WHERE id(x) = row.match.id SET x += row.props RETURN x
+func (k *Khadijah) UpdateNodesWithMatch(entities interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.updateNodesWithMatch(entities, label, matchClause, withReturn, excludes...)
+}
+
+// UpdateNodes works like UpdateNodesWithMatch, but uses the default match clause
+func (k *Khadijah) UpdateNodes(entities interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
+	return k.UpdateNodesWithMatch(entities, label, k.matchClause(), withReturn, excludes...)
+}
+
+// DeleteNodesWithMatch builds a single UNWIND ... MATCH ... DELETE query for a
+// slice of entities
+//		UNWIND $rows AS row MATCH (x) WHERE id(x) = row.id [DETACH] DELETE x
+func (k *Khadijah) DeleteNodesWithMatch(entities interface{}, detach bool, matchClause Clause) *Maxine {
+	reg := newRegine(k.matchClause(), k.RootMaxx)
+
+	return reg.deleteNodesWithMatch(entities, detach, matchClause)
+}
+
+// DeleteNodes works like DeleteNodesWithMatch, but uses the default match clause
+func (k *Khadijah) DeleteNodes(entities interface{}, detach bool) *Maxine {
+	return k.DeleteNodesWithMatch(entities, detach, k.matchClause())
+}
+
+// CreateEdges builds a single UNWIND ... CREATE query for a slice of Links
+// using the default match clause for both nodes
+//		UNWIND $rows AS row MATCH (start:Label) WHERE id(start) = row.start.id MATCH (end:Label) WHERE id(end) = row.end.id CREATE (start)-[edge:label]->(end) SET edge = row.edge RETURN start, edge, end
+func (k *Khadijah) CreateEdges(links []Link, direction string, startLabel, endLabel, edgeLabel *string, withReturn bool, excludes ...string) *Maxine {
+	return k.CreateEdgesWithMatches(links, startLabel, DefaultMatchClause, direction, endLabel, DefaultMatchClause, edgeLabel, withReturn, excludes...)
+}
+
+// CreateEdgesWithMatches works like CreateEdges, but with custom match clauses
+// for the start and end nodes
+func (k *Khadijah) CreateEdgesWithMatches(links []Link, startLabel *string, startMatchClause Clause, direction string, endLabel *string, endMatchClause Clause, edgeLabel *string, withReturn bool, excludes ...string) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.createEdgesWithMatches(links, startLabel, startMatchClause, direction, endLabel, endMatchClause, edgeLabel, withReturn, excludes...)
+}
+
+// MatchPath builds a cypher MATCH query for a path of alternating nodes and
+// edges. Nodes use the variables n0, n1, ... and edges r0, r1, ... with their
+// params prefixed by their variable. Patterns only hold the key properties,
+// elements without them are found with their match clause
+//		MATCH (n0:User {id: $n0_id})-[r0:MEMBER_OF]->(n1:Team)-[r1:OWNS]->(n2:Repo) WHERE id(n1) = $n1_id RETURN n0, r0, n1, r1, n2
+func (k *Khadijah) MatchPath(elements []PathElement, withReturn bool) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.path(KeywordMatch, elements, withReturn)
+}
+
+// CreatePath works like MatchPath, but builds a CREATE query
+//		CREATE (n0:User {id: $n0_id})-[r0:MEMBER_OF]->(n1:Team {name: $n1_name}) RETURN n0, r0, n1
+func (k *Khadijah) CreatePath(elements []PathElement, withReturn bool) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.path(KeywordCreate, elements, withReturn)
+}
+
+// MergePath works like MatchPath, but builds a MERGE query. Nodes are merged
+// on their key properties and the rest are SET on create and on match
+//		MERGE (n0:User {id: $n0_id})-[r0:MEMBER_OF]->(n1:Team {name: $n1_name}) ON CREATE SET n0.email = $n0_email ON MATCH SET n0.email = $n0_email RETURN n0, r0, n1
+func (k *Khadijah) MergePath(elements []PathElement, withReturn bool) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.path(KeywordMerge, elements, withReturn)
+}
+
+// TraverseWithMatch builds a cypher query that follows variable length
+// relationships from the start node. The shortest modes return the path
+//		MATCH (start:Label) WHERE start.param = $start_param MATCH (start)-[:T1|T2*1..3]->(end:Label) RETURN end
+//		MATCH (start:Label) WHERE start.param = $start_param MATCH path = shortestPath((start)-[:T*..3]->(end:Label)) RETURN path
+func (k *Khadijah) TraverseWithMatch(start interface{}, startLabel *string, startMatchClause Clause, traversal Traversal, withReturn bool) *Maxine {
+	syn := newSynclaire(k.matchClause(), k.StartVariable, k.EndVariable, k.AllowUnboundedDelete, k.RootMaxx)
+
+	return syn.traverse(start, startLabel, startMatchClause, traversal, withReturn)
+}
+
+// Traverse works like TraverseWithMatch, but defaults the startMatchClause to {id: $start_id}
+//		MATCH (start:Label) WHERE id(start) = $start_id MATCH (start)-[:T*1..3]->(end) RETURN end
+func (k *Khadijah) Traverse(start interface{}, startLabel *string, traversal Traversal, withReturn bool) *Maxine {
+	return k.TraverseWithMatch(start, startLabel, k.matchClause(), traversal, withReturn)
+}
+
+// Query creates a Kyle, a builder that composes queries from strings and the
+// fragments of the Maxine instances that the other functions return
+//		instance.Query().Match(user).Where(match).Set(update).Return(user).Build()
+func (k *Khadijah) Query() *Kyle {
+	return newKyle(k.RootMaxx)
+}
+
+// Hydrate fills the struct that dest points to with the properties found in
+// the record using the same tag rules that are used to build the queries
+//		k.Hydrate(M{"id": "someID", "name": "emehrkay"}, &user)
+func (k *Khadijah) Hydrate(record interface{}, dest interface{}) error {
+	return k.RootMaxx.Hydrate(record, dest)
+}
+
+// the E functions work exactly like their counterparts, but they return
+// Maxine.Err so that bad input can be caught before the query is sent
+
+// NodeWithPropertiesE works like NodeWithProperties but returns any error found
+func (k *Khadijah) NodeWithPropertiesE(entity interface{}, label *string) (*Maxine, error) {
+	maxx := k.NodeWithProperties(entity, label)
+
+	return maxx, maxx.Err
+}
+
+// MatchNodeE works like MatchNode but returns any error found
+func (k *Khadijah) MatchNodeE(entity interface{}, label *string, withReturn bool) (*Maxine, error) {
+	maxx := k.MatchNode(entity, label, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// CreateNodeE works like CreateNode but returns any error found
+func (k *Khadijah) CreateNodeE(entity interface{}, label *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.CreateNode(entity, label, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateNodeWithMatchE works like UpdateNodeWithMatch but returns any error found
+func (k *Khadijah) UpdateNodeWithMatchE(entity interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpdateNodeWithMatch(entity, label, matchClause, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateNodeE works like UpdateNode but returns any error found
+func (k *Khadijah) UpdateNodeE(entity interface{}, label *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpdateNode(entity, label, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateLabelsWithMatchE works like UpdateLabelsWithMatch but returns any error found
+func (k *Khadijah) UpdateLabelsWithMatchE(entity interface{}, label *string, matchClause Clause, add, remove []string, withReturn bool) (*Maxine, error) {
+	maxx := k.UpdateLabelsWithMatch(entity, label, matchClause, add, remove, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// UpdateLabelsE works like UpdateLabels but returns any error found
+func (k *Khadijah) UpdateLabelsE(entity interface{}, label *string, add, remove []string, withReturn bool) (*Maxine, error) {
+	maxx := k.UpdateLabels(entity, label, add, remove, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// DeleteNodeWithMatchE works like DeleteNodeWithMatch but returns any error found
+func (k *Khadijah) DeleteNodeWithMatchE(entity interface{}, detach bool, matchClause Clause) (*Maxine, error) {
+	maxx := k.DeleteNodeWithMatch(entity, detach, matchClause)
+
+	return maxx, maxx.Err
+}
+
+// DetachDeleteNodeWithMatchE works like DetachDeleteNodeWithMatch but returns any error found
+func (k *Khadijah) DetachDeleteNodeWithMatchE(entity interface{}, matchClause Clause) (*Maxine, error) {
+	maxx := k.DetachDeleteNodeWithMatch(entity, matchClause)
+
+	return maxx, maxx.Err
+}
+
+// DetachDeleteNodeE works like DetachDeleteNode but returns any error found
+func (k *Khadijah) DetachDeleteNodeE(entity interface{}) (*Maxine, error) {
+	maxx := k.DetachDeleteNode(entity)
+
+	return maxx, maxx.Err
+}
+
+// DeleteNodeE works like DeleteNode but returns any error found
+func (k *Khadijah) DeleteNodeE(entity interface{}, detach bool) (*Maxine, error) {
+	maxx := k.DeleteNode(entity, detach)
+
+	return maxx, maxx.Err
+}
+
+// CreateEdgeE works like CreateEdge but returns any error found
+func (k *Khadijah) CreateEdgeE(start, end, edge interface{}, direction string, startLabel *string, endLabel, edgeLabel *string, withReturn bool, excldues ...string) (*Maxine, error) {
+	maxx := k.CreateEdge(start, end, edge, direction, startLabel, endLabel, edgeLabel, withReturn, excldues...)
+
+	return maxx, maxx.Err
+}
+
+// CreateEdgeWithMatchesE works like CreateEdgeWithMatches but returns any error found
+func (k *Khadijah) CreateEdgeWithMatchesE(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) (*Maxine, error) {
+	maxx := k.CreateEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, withReturn, excldues...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateEdgeWithMatchesE works like UpdateEdgeWithMatches but returns any error found
+func (k *Khadijah) UpdateEdgeWithMatchesE(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, edgeMatchClause Clause, withReturn bool, excldues ...string) (*Maxine, error) {
+	maxx := k.UpdateEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, edgeMatchClause, withReturn, excldues...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateEdgeE works like UpdateEdge but returns any error found
+func (k *Khadijah) UpdateEdgeE(start interface{}, startLabel *string, direction string, end interface{}, endLabel *string, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) (*Maxine, error) {
+	maxx := k.UpdateEdge(start, startLabel, direction, end, endLabel, edge, edgeLabel, withReturn, excldues...)
+
+	return maxx, maxx.Err
+}
+
+// DeleteEdgeWithMatchingLabelsE works like DeleteEdgeWithMatchingLabels but returns any error found
+func (k *Khadijah) DeleteEdgeWithMatchingLabelsE(startLabel, direction, endLabel string, edge interface{}, edgeLabel string, edgeMatchClause Clause) (*Maxine, error) {
+	maxx := k.DeleteEdgeWithMatchingLabels(startLabel, direction, endLabel, edge, edgeLabel, edgeMatchClause)
+
+	return maxx, maxx.Err
+}
+
+// DeleteEdgeE works like DeleteEdge but returns any error found
+func (k *Khadijah) DeleteEdgeE(edge interface{}, edgeLabel, direction string, edgeMatchClause Clause) (*Maxine, error) {
+	maxx := k.DeleteEdge(edge, edgeLabel, direction, edgeMatchClause)
+
+	return maxx, maxx.Err
+}
+
+// CreateNodesE works like CreateNodes but returns any error found
+func (k *Khadijah) CreateNodesE(entities interface{}, label *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.CreateNodes(entities, label, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateNodesWithMatchE works like UpdateNodesWithMatch but returns any error found
+func (k *Khadijah) UpdateNodesWithMatchE(entities interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpdateNodesWithMatch(entities, label, matchClause, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateNodesE works like UpdateNodes but returns any error found
+func (k *Khadijah) UpdateNodesE(entities interface{}, label *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpdateNodes(entities, label, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// DeleteNodesWithMatchE works like DeleteNodesWithMatch but returns any error found
+func (k *Khadijah) DeleteNodesWithMatchE(entities interface{}, detach bool, matchClause Clause) (*Maxine, error) {
+	maxx := k.DeleteNodesWithMatch(entities, detach, matchClause)
+
+	return maxx, maxx.Err
+}
+
+// DeleteNodesE works like DeleteNodes but returns any error found
+func (k *Khadijah) DeleteNodesE(entities interface{}, detach bool) (*Maxine, error) {
+	maxx := k.DeleteNodes(entities, detach)
+
+	return maxx, maxx.Err
+}
+
+// CreateEdgesE works like CreateEdges but returns any error found
+func (k *Khadijah) CreateEdgesE(links []Link, direction string, startLabel, endLabel, edgeLabel *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.CreateEdges(links, direction, startLabel, endLabel, edgeLabel, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// CreateEdgesWithMatchesE works like CreateEdgesWithMatches but returns any error found
+func (k *Khadijah) CreateEdgesWithMatchesE(links []Link, startLabel *string, startMatchClause Clause, direction string, endLabel *string, endMatchClause Clause, edgeLabel *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.CreateEdgesWithMatches(links, startLabel, startMatchClause, direction, endLabel, endMatchClause, edgeLabel, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// RemovePropertiesWithMatchE works like RemovePropertiesWithMatch but returns any error found
+func (k *Khadijah) RemovePropertiesWithMatchE(entity interface{}, label *string, matchClause Clause, props ...string) (*Maxine, error) {
+	maxx := k.RemovePropertiesWithMatch(entity, label, matchClause, props...)
+
+	return maxx, maxx.Err
+}
+
+// RemovePropertiesE works like RemoveProperties but returns any error found
+func (k *Khadijah) RemovePropertiesE(entity interface{}, label *string, props ...string) (*Maxine, error) {
+	maxx := k.RemoveProperties(entity, label, props...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateNodeDiffWithMatchE works like UpdateNodeDiffWithMatch but returns any error found
+func (k *Khadijah) UpdateNodeDiffWithMatchE(before, after interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpdateNodeDiffWithMatch(before, after, label, matchClause, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpdateNodeDiffE works like UpdateNodeDiff but returns any error found
+func (k *Khadijah) UpdateNodeDiffE(before, after interface{}, label *string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpdateNodeDiff(before, after, label, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpsertNodeE works like UpsertNode but returns any error found
+func (k *Khadijah) UpsertNodeE(entity interface{}, label *string, keyFields []string, withReturn bool, excludes ...string) (*Maxine, error) {
+	maxx := k.UpsertNode(entity, label, keyFields, withReturn, excludes...)
+
+	return maxx, maxx.Err
+}
+
+// UpsertEdgeWithMatchesE works like UpsertEdgeWithMatches but returns any error found
+func (k *Khadijah) UpsertEdgeWithMatchesE(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, keyFields []string, withReturn bool, excldues ...string) (*Maxine, error) {
+	maxx := k.UpsertEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, keyFields, withReturn, excldues...)
+
+	return maxx, maxx.Err
+}
+
+// UpsertEdgeE works like UpsertEdge but returns any error found
+func (k *Khadijah) UpsertEdgeE(start interface{}, startLabel *string, direction string, end interface{}, endLabel *string, edge interface{}, edgeLabel *string, keyFields []string, withReturn bool, excldues ...string) (*Maxine, error) {
+	maxx := k.UpsertEdge(start, startLabel, direction, end, endLabel, edge, edgeLabel, keyFields, withReturn, excldues...)
+
+	return maxx, maxx.Err
+}
+
+// DeleteEdgeWithMatchesE works like DeleteEdgeWithMatches but returns any error found
+func (k *Khadijah) DeleteEdgeWithMatchesE(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, edgeMatchClause Clause) (*Maxine, error) {
+	maxx := k.DeleteEdgeWithMatches(start, startLabel, startMatchClause, direction, end, endLabel, endMatchClause, edge, edgeLabel, edgeMatchClause)
+
+	return maxx, maxx.Err
+}
+
+// DeleteEdgeBetweenE works like DeleteEdgeBetween but returns any error found
+func (k *Khadijah) DeleteEdgeBetweenE(start interface{}, startLabel *string, direction string, end interface{}, endLabel *string, edgeLabel string) (*Maxine, error) {
+	maxx := k.DeleteEdgeBetween(start, startLabel, direction, end, endLabel, edgeLabel)
+
+	return maxx, maxx.Err
+}
+
+// MatchPathE works like MatchPath but returns any error found
+func (k *Khadijah) MatchPathE(elements []PathElement, withReturn bool) (*Maxine, error) {
+	maxx := k.MatchPath(elements, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// CreatePathE works like CreatePath but returns any error found
+func (k *Khadijah) CreatePathE(elements []PathElement, withReturn bool) (*Maxine, error) {
+	maxx := k.CreatePath(elements, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// MergePathE works like MergePath but returns any error found
+func (k *Khadijah) MergePathE(elements []PathElement, withReturn bool) (*Maxine, error) {
+	maxx := k.MergePath(elements, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// TraverseWithMatchE works like TraverseWithMatch but returns any error found
+func (k *Khadijah) TraverseWithMatchE(start interface{}, startLabel *string, startMatchClause Clause, traversal Traversal, withReturn bool) (*Maxine, error) {
+	maxx := k.TraverseWithMatch(start, startLabel, startMatchClause, traversal, withReturn)
+
+	return maxx, maxx.Err
+}
+
+// TraverseE works like Traverse but returns any error found
+func (k *Khadijah) TraverseE(start interface{}, startLabel *string, traversal Traversal, withReturn bool) (*Maxine, error) {
+	maxx := k.Traverse(start, startLabel, traversal, withReturn)
+
+	return maxx, maxx.Err
+}
@@ -1,9 +1,13 @@
 package khadijah_test
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	k "github.com/emehrkay/khadijah"
 )
@@ -626,6 +630,2865 @@ func TestCreateEdgeSuite(t *testing.T) {
 	}
 }
 
+type Rated struct {
+	ID    string `json:"id"`
+	Score int    `json:"score"`
+	Note  string `json:"note,omitempty"`
+}
+
 func TestUpdateEdgeSuite(t *testing.T) {
+	type Update struct {
+		name     string
+		build    func(instance *k.Khadijah) *k.Maxine
+		expected string
+		params   k.M
+	}
+
+	rated := Rated{ID: "edgeID", Score: 5}
+	ratedLabel := "RATED"
+	tests := []Update{
+		{
+			"update edge with default matches and return",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateEdge(userJ, nil, "out", userJ, userLabel, rated, &ratedLabel, true)
+			},
+			"MATCH (start:TestJsonUser) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:RATED]->(end) WHERE id(flava) = $id SET flava.id = $id, flava.score = $score RETURN start, flava, end",
+			k.M{
+				"start_id": userJ.ID, "start_name": userJ.Name, "start_email": userJ.Email,
+				"end_id": userJ.ID, "end_name": userJ.Name, "end_email": userJ.Email,
+				"id": rated.ID, "score": rated.Score,
+			},
+		},
+		{
+			"update edge with custom matches without a return",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateEdgeWithMatches(userJ, userLabel, k.M{"+v+.email": "email"}, "in", userJ, userLabel, k.M{"+v+.name": "name"}, rated, nil, k.OM{{"+v+.score", "score"}}, false, "id")
+			},
+			"MATCH (start:user) WHERE start.email = $start_email MATCH (end:user) WHERE end.name = $end_name MATCH (start)<-[flava:Rated]-(end) WHERE flava.score = $score SET flava.score = $score",
+			k.M{
+				"start_id": userJ.ID, "start_name": userJ.Name, "start_email": userJ.Email,
+				"end_id": userJ.ID, "end_name": userJ.Name, "end_email": userJ.Email,
+				"id": rated.ID, "score": rated.Score,
+			},
+		},
+		{
+			"update every edge between the nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateEdgeWithMatches(userJ, userLabel, k.DefaultMatchClause, "", userJ, userLabel, k.DefaultMatchClause, follows, nil, nil, false)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:Follows]-(end) SET flava.since = $since",
+			k.M{
+				"start_id": userJ.ID, "start_name": userJ.Name, "start_email": userJ.Email,
+				"end_id": userJ.ID, "end_name": userJ.Name, "end_email": userJ.Email,
+				"since": follows.Since,
+			},
+		},
+		{
+			"upsert edge on its key fields",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpsertEdge(userJ, userLabel, "out", userJ, userLabel, rated, &ratedLabel, []string{"id"}, true)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MERGE (start)-[flava:RATED {id: $id}]->(end) ON CREATE SET flava.score = $score ON MATCH SET flava.score = $score RETURN start, flava, end",
+			k.M{
+				"start_id": userJ.ID, "start_name": userJ.Name, "start_email": userJ.Email,
+				"end_id": userJ.ID, "end_name": userJ.Name, "end_email": userJ.Email,
+				"id": rated.ID, "score": rated.Score,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.build(k.New())
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if !reflect.DeepEqual(maxx.Params, test.params) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.params, maxx.Params)
+			}
+		})
+	}
+
+	t.Run("custom tag is used for the nodes", func(t *testing.T) {
+		type CustomRated struct {
+			Score int `custom:"score"`
+		}
+
+		instance := k.New(k.SetTagName("custom"))
+		maxx, err := instance.UpdateEdgeWithMatchesE(userC, userLabel, k.DefaultMatchClause, "out", userC, userLabel, k.DefaultMatchClause, CustomRated{Score: 1}, &ratedLabel, nil, false)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if maxx.Params["start_id"] != userC.ID || maxx.Params["end_id"] != userC.ID {
+			t.Errorf("expected the node params to be read with the custom tag, got %v", maxx.Params)
+		}
+	})
+}
+
+type TestTaggedUser struct {
+	ID        string `json:"id" khadijah:",key"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Age       int    `json:"age,string"`
+	CreatedAt string `json:"createdAt" khadijah:"created_at,readonly"`
+	Secret    string `json:"-"`
+	Ignored   string
+}
+
+func TestParseTagOptions(t *testing.T) {
+	type Parse struct {
+		name        string
+		user        TestTaggedUser
+		createQuery string
+		setQuery    string
+		params      k.M
+	}
+
+	tests := []Parse{
+		{
+			"all fields set",
+			TestTaggedUser{ID: "1", Name: "mark", Email: "spam@aol.com", Age: 40, CreatedAt: "today", Secret: "x", Ignored: "y"},
+			"{id: $id, name: $name, email: $email, age: $age, created_at: $created_at}",
+			"flava.name = $name, flava.email = $email, flava.age = $age",
+			k.M{"id": "1", "name": "mark", "email": "spam@aol.com", "age": "40", "created_at": "today"},
+		},
+		{
+			"omitempty fields are left out",
+			TestTaggedUser{ID: "1", Age: 0, CreatedAt: "today"},
+			"{id: $id, age: $age, created_at: $created_at}",
+			"flava.age = $age",
+			k.M{"id": "1", "age": "0", "created_at": "today"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := k.New().RootMaxx.Parse(test.user)
+
+			if maxx.CreateQuery != test.createQuery {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.createQuery, maxx.CreateQuery)
+			}
+
+			if maxx.SetQuery != test.setQuery {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.setQuery, maxx.SetQuery)
+			}
+
+			if !reflect.DeepEqual(maxx.Params, test.params) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.params, maxx.Params)
+			}
+		})
+	}
+}
+
+func TestMatchClauseOrder(t *testing.T) {
+	type Order struct {
+		name        string
+		expected    string
+		matchClause k.Clause
+	}
+
+	instance := k.New()
+	tests := []Order{
+		{
+			"map clauses are sorted by key",
+			"MATCH (flava:user) WHERE flava.email = $email AND flava.name = $name SET flava.id = $id, flava.name = $name, flava.email = $email",
+			k.M{"flava.name": "name", "flava.email": "email"},
+		},
+		{
+			"ordered clauses keep their order",
+			"MATCH (flava:user) WHERE flava.name = $name AND flava.email = $email SET flava.id = $id, flava.name = $name, flava.email = $email",
+			k.OM{{"+v+.name", "name"}, {"+v+.email", "email"}},
+		},
+		{
+			"ordered clauses keep their order when unsorted",
+			"MATCH (flava:user) WHERE flava.email = $email AND id(flava) = $id SET flava.id = $id, flava.name = $name, flava.email = $email",
+			k.OM{{"+v+.email", "email"}, {"id(+v+)", "id"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				maxx := instance.UpdateNodeWithMatch(userJ, userLabel, test.matchClause, false)
+
+				if maxx.Query != test.expected {
+					t.Fatalf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+				}
+			}
+		})
+	}
+
+	t.Run("ordered default match clause", func(t *testing.T) {
+		settings := []k.KhadijahSetting{
+			k.SetOrderedMatchClause(k.OM{{"+v+.name", "name"}, {"id(+v+)", "id"}}),
+		}
+		expected := "MATCH (flava:user) WHERE flava.name = $name AND id(flava) = $id SET flava.id = $id, flava.name = $name, flava.email = $email"
+
+		for i := 0; i < 20; i++ {
+			maxx := k.New(settings...).UpdateNode(userJ, userLabel, false)
+
+			if maxx.Query != expected {
+				t.Fatalf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+			}
+		}
+
+		// a later SetMatchClause replaces the ordered clause
+		settings = append(settings, k.SetMatchClause(k.M{"+v+.email": "email"}))
+		expected = "MATCH (flava:user) WHERE flava.email = $email SET flava.id = $id, flava.name = $name, flava.email = $email"
+		maxx := k.New(settings...).UpdateNode(userJ, userLabel, false)
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	type Untagged struct {
+		Name string
+	}
+
+	type Errors struct {
+		name     string
+		build    func(instance *k.Khadijah) (*k.Maxine, error)
+		expected error
+	}
+
+	var nilUser *TestJsonUser
+	emptyLabel := ""
+	tests := []Errors{
+		{
+			"valid entity has no error",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateNodeE(userJ, userLabel, true)
+			},
+			nil,
+		},
+		{
+			"pointer entity has no error",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateNodeE(&userJ, userLabel, true)
+			},
+			nil,
+		},
+		{
+			"nil entity",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateNodeE(nil, userLabel, true)
+			},
+			k.ErrNilEntity,
+		},
+		{
+			"nil pointer entity",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateNodeE(nilUser, userLabel, true)
+			},
+			k.ErrNilEntity,
+		},
+		{
+			"map entity",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateNodeE(k.M{"id": "id"}, userLabel, true)
+			},
+			k.ErrNotStruct,
+		},
+		{
+			"entity without tagged fields",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateNodeE(Untagged{Name: "mark"}, userLabel, true)
+			},
+			k.ErrNoProperties,
+		},
+		{
+			"empty label",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MatchNodeE(userJ, &emptyLabel, true)
+			},
+			k.ErrEmptyLabel,
+		},
+		{
+			"missing match param",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeWithMatchE(userJ, userLabel, k.M{"+v+.uuid": "uuid"}, true)
+			},
+			k.ErrMissingMatchParam,
+		},
+		{
+			"empty match clause",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteNodeWithMatchE(userJ, true, k.OM{})
+			},
+			k.ErrEmptyMatchClause,
+		},
+		{
+			"edge errors are returned",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreateEdgeE(userJ, nilUser, knows, "out", nil, nil, nil, true)
+			},
+			k.ErrNilEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.build(k.New())
+
+			if !errors.Is(err, test.expected) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.expected, err)
+			}
+
+			if maxx == nil {
+				t.Errorf("expected a Maxine instance even when there is an error")
+			}
+		})
+	}
+}
+
+func TestBatchSuite(t *testing.T) {
+	type Batch struct {
+		name     string
+		build    func(instance *k.Khadijah) *k.Maxine
+		expected string
+		rows     []interface{}
+	}
+
+	users := []TestJsonUser{
+		{ID: "1", Name: "one", Email: "one@aol.com"},
+		{ID: "2", Name: "two", Email: "two@aol.com"},
+	}
+	tests := []Batch{
+		{
+			"create nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateNodes(users, userLabel, true, "email")
+			},
+			"UNWIND $rows AS row CREATE (flava:user) SET flava = row RETURN flava",
+			[]interface{}{
+				map[string]interface{}{"id": "1", "name": "one"},
+				map[string]interface{}{"id": "2", "name": "two"},
+			},
+		},
+		{
+			"update nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNodes(users, userLabel, false, "id")
+			},
+			"UNWIND $rows AS row MATCH (flava:user) WHERE id(flava) = row.match.id SET flava += row.props",
+			[]interface{}{
+				map[string]interface{}{
+					"match": map[string]interface{}{"id": "1"},
+					"props": map[string]interface{}{"name": "one", "email": "one@aol.com"},
+				},
+				map[string]interface{}{
+					"match": map[string]interface{}{"id": "2"},
+					"props": map[string]interface{}{"name": "two", "email": "two@aol.com"},
+				},
+			},
+		},
+		{
+			"update versioned nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNodes([]TestVersioned{{ID: "1", Name: "max", Version: 3}}, userLabel, false)
+			},
+			"UNWIND $rows AS row MATCH (flava:user) WHERE id(flava) = row.match.id AND flava.version = row.match.version SET flava += row.props, flava.version = flava.version + 1 RETURN flava",
+			[]interface{}{
+				map[string]interface{}{
+					"match": map[string]interface{}{"id": "1", "version": 3},
+					"props": map[string]interface{}{"id": "1", "name": "max"},
+				},
+			},
+		},
+		{
+			"delete nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.DeleteNodesWithMatch(users, true, k.M{"+v+.email": "email"})
+			},
+			"UNWIND $rows AS row MATCH (flava) WHERE flava.email = row.email DETACH DELETE flava",
+			[]interface{}{
+				map[string]interface{}{"email": "one@aol.com"},
+				map[string]interface{}{"email": "two@aol.com"},
+			},
+		},
+		{
+			"create edges",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateEdges([]k.Link{
+					{Start: users[0], End: users[1], Edge: follows},
+				}, "out", nil, nil, nil, true)
+			},
+			"UNWIND $rows AS row MATCH (start:TestJsonUser) WHERE id(start) = row.start.id MATCH (end:TestJsonUser) WHERE id(end) = row.end.id CREATE (start)-[flava:Follows]->(end) SET flava = row.edge RETURN start, flava, end",
+			[]interface{}{
+				map[string]interface{}{
+					"start": map[string]interface{}{"id": "1"},
+					"end":   map[string]interface{}{"id": "2"},
+					"edge":  map[string]interface{}{"since": "yesterday"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.build(k.New())
+
+			if maxx.Err != nil {
+				t.Fatalf("unexpected error: %v", maxx.Err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if !reflect.DeepEqual(maxx.Params["rows"], test.rows) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.rows, maxx.Params["rows"])
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		instance := k.New()
+
+		if _, err := instance.CreateNodesE(userJ, userLabel, true); !errors.Is(err, k.ErrNotSlice) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrNotSlice, err)
+		}
+
+		if _, err := instance.CreateNodesE([]TestJsonUser{}, userLabel, true); !errors.Is(err, k.ErrEmptyBatch) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrEmptyBatch, err)
+		}
+	})
+}
+
+func TestUpsertNode(t *testing.T) {
+	type Upsert struct {
+		name      string
+		user      interface{}
+		keyFields []string
+		expected  string
+		err       error
+	}
+
+	tagged := TestTaggedUser{ID: "1", Name: "mark", Age: 40, CreatedAt: "today"}
+	tests := []Upsert{
+		{
+			"upsert with key fields",
+			userJ,
+			[]string{"id"},
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava.name = $name, flava.email = $email ON MATCH SET flava.name = $name, flava.email = $email RETURN flava",
+			nil,
+		},
+		{
+			"upsert with tagged key and readonly fields",
+			tagged,
+			nil,
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava.name = $name, flava.age = $age, flava.created_at = $created_at ON MATCH SET flava.name = $name, flava.age = $age RETURN flava",
+			nil,
+		},
+		{
+			"upsert with multiple key fields",
+			userJ,
+			[]string{"email", "name"},
+			"MERGE (flava:user {email: $email, name: $name}) ON CREATE SET flava.id = $id ON MATCH SET flava.id = $id RETURN flava",
+			nil,
+		},
+		{
+			"upsert without key fields",
+			userJ,
+			nil,
+			"MERGE (flava:user {}) ON CREATE SET flava.id = $id, flava.name = $name, flava.email = $email ON MATCH SET flava.id = $id, flava.name = $name, flava.email = $email RETURN flava",
+			k.ErrNoKeyFields,
+		},
+		{
+			"upsert with unknown key field",
+			userJ,
+			[]string{"uuid"},
+			"MERGE (flava:user {}) ON CREATE SET flava.id = $id, flava.name = $name, flava.email = $email ON MATCH SET flava.id = $id, flava.name = $name, flava.email = $email RETURN flava",
+			k.ErrMissingMatchParam,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := k.New().UpsertNodeE(test.user, userLabel, test.keyFields, true)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+		})
+	}
+}
+
+func TestDeleteEdgeSuite(t *testing.T) {
+	type Delete struct {
+		name     string
+		build    func(instance *k.Khadijah) (*k.Maxine, error)
+		expected string
+		err      error
+	}
+
+	rated := Rated{ID: "edgeID", Score: 5}
+	ratedLabel := "RATED"
+	tests := []Delete{
+		{
+			"delete edge with a match clause",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeE(rated, ratedLabel, "out", k.DefaultMatchClause)
+			},
+			"MATCH ()-[flava:RATED]->() WHERE id(flava) = $id DELETE flava",
+			nil,
+		},
+		{
+			"delete edge without a match clause is refused",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeE(rated, ratedLabel, "out", nil)
+			},
+			"",
+			k.ErrUnboundedDelete,
+		},
+		{
+			"delete edge without a match clause when allowed",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				instance.Apply(k.SetAllowUnboundedDelete(true))
+
+				return instance.DeleteEdgeE(rated, ratedLabel, "out", nil)
+			},
+			"MATCH ()-[flava:RATED]->() DELETE flava",
+			nil,
+		},
+		{
+			"delete edge with matching labels",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeWithMatchingLabelsE("User", "in", "Team", rated, ratedLabel, k.M{"+v+.score": "score"})
+			},
+			"MATCH (:User)<-[flava:RATED]-(:Team) WHERE flava.score = $score DELETE flava",
+			nil,
+		},
+		{
+			"delete edge with matching labels without a match clause is refused",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeWithMatchingLabelsE("User", "in", "Team", rated, ratedLabel, k.M{})
+			},
+			"",
+			k.ErrUnboundedDelete,
+		},
+		{
+			"delete edge between nodes",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeBetweenE(userJ, userLabel, "out", userJ, userLabel, ratedLabel)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:RATED]->(end) DELETE flava",
+			nil,
+		},
+		{
+			"delete edge between nodes with an edge match clause",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeWithMatchesE(userJ, userLabel, k.DefaultMatchClause, "", userJ, userLabel, k.DefaultMatchClause, rated, nil, k.M{"+v+.score": "score"})
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:Rated]-(end) WHERE flava.score = $score DELETE flava",
+			nil,
+		},
+		{
+			"delete edge between nodes without an edge or a label",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.DeleteEdgeWithMatchesE(userJ, userLabel, k.DefaultMatchClause, "", userJ, userLabel, k.DefaultMatchClause, nil, nil, nil)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:]-(end) DELETE flava",
+			k.ErrEmptyLabel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.build(k.New())
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+		})
+	}
+}
+
+type testNode struct {
+	props map[string]interface{}
+}
+
+func (n testNode) GetProperties() map[string]interface{} {
+	return n.props
+}
 
+type TestHydrated struct {
+	ID       string    `json:"id"`
+	Age      int       `json:"age"`
+	Score    float64   `json:"score"`
+	Count    uint8     `json:"count,string"`
+	Active   bool      `json:"active"`
+	Tags     []string  `json:"tags"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+	Nickname *string   `json:"nickname"`
+	Skipped  string    `json:"-"`
+}
+
+func TestHydrate(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	nickname := "mehrkay"
+	props := map[string]interface{}{
+		"id":       "someID",
+		"age":      int64(40),
+		"score":    int64(10),
+		"count":    "7",
+		"active":   true,
+		"tags":     []interface{}{"a", "b"},
+		"created":  created,
+		"updated":  "2020-01-02T03:04:05Z",
+		"nickname": "mehrkay",
+		"-":        "skipped",
+	}
+	expected := TestHydrated{
+		ID:       "someID",
+		Age:      40,
+		Score:    10,
+		Count:    7,
+		Active:   true,
+		Tags:     []string{"a", "b"},
+		Created:  created,
+		Updated:  created,
+		Nickname: &nickname,
+	}
+
+	type Hydrate struct {
+		name   string
+		record interface{}
+	}
+
+	tests := []Hydrate{
+		{"from a map", props},
+		{"from an M", k.M(props)},
+		{"from a node", testNode{props}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user := TestHydrated{}
+			if err := k.New().Hydrate(test.record, &user); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(user, expected) {
+				t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", expected, user)
+			}
+		})
+	}
+
+	t.Run("round trip with a custom tag", func(t *testing.T) {
+		instance := k.New(k.SetTagName("custom"))
+		maxx := instance.CreateNode(userC, nil, false)
+		user := TestCustomUser{}
+
+		if err := instance.Hydrate(maxx.Params, &user); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user != userC {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", userC, user)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		instance := k.New()
+		user := TestHydrated{}
+
+		if err := instance.Hydrate(props, user); !errors.Is(err, k.ErrNotPointer) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrNotPointer, err)
+		}
+
+		if err := instance.Hydrate("nope", &user); !errors.Is(err, k.ErrNotRecord) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrNotRecord, err)
+		}
+
+		if err := instance.Hydrate(k.M{"age": "forty"}, &user); !errors.Is(err, k.ErrCannotHydrate) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrCannotHydrate, err)
+		}
+
+		if err := instance.Hydrate(k.M{"count": 1000}, &user); !errors.Is(err, k.ErrCannotHydrate) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrCannotHydrate, err)
+		}
+	})
+}
+
+type TestDashedUser struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first-name"`
+}
+
+func TestIdentifierEscaping(t *testing.T) {
+	type Escape struct {
+		name     string
+		settings []k.KhadijahSetting
+		label    string
+		expected string
+		err      error
+	}
+
+	user := TestDashedUser{ID: "1", FirstName: "mark"}
+	tests := []Escape{
+		{
+			"unsafe label and property are quoted",
+			[]k.KhadijahSetting{},
+			"Special User",
+			"MATCH (flava:`Special User`) WHERE id(flava) = $id SET flava.id = $id, flava.`first-name` = $`first-name`",
+			nil,
+		},
+		{
+			"backticks are escaped",
+			[]k.KhadijahSetting{},
+			"User`) DETACH DELETE flava //",
+			"MATCH (flava:`User``) DETACH DELETE flava //`) WHERE id(flava) = $id SET flava.id = $id, flava.`first-name` = $`first-name`",
+			nil,
+		},
+		{
+			"strict identifiers are rejected",
+			[]k.KhadijahSetting{k.SetStrictIdentifiers(true)},
+			"User",
+			"MATCH (flava:User) WHERE id(flava) = $id SET flava.id = $id, flava.first-name = $first-name",
+			k.ErrUnsafeIdentifier,
+		},
+		{
+			"invalid variable",
+			[]k.KhadijahSetting{k.SetVariable("fla va")},
+			"User",
+			"MATCH (fla va:User) WHERE id(fla va) = $id SET fla va.id = $id, fla va.`first-name` = $`first-name`",
+			k.ErrInvalidVariable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := k.New(test.settings...)
+			maxx, err := instance.UpdateNodeE(user, &test.label, false)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if maxx.Params["first-name"] != user.FirstName {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", user.FirstName, maxx.Params["first-name"])
+			}
+		})
+	}
+
+	t.Run("edge types are quoted", func(t *testing.T) {
+		edgeLabel := "IS-FRIENDS-WITH"
+		maxx := k.New().CreateEdge(userJ, userJ, knows, "out", userLabel, userLabel, &edgeLabel, false)
+		expected := "MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id CREATE (start)-[flava:`IS-FRIENDS-WITH` ]->(end)"
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+	})
+}
+
+type TestAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type TestAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type TestNestedUser struct {
+	TestAudit
+	ID       string       `json:"id"`
+	Address  TestAddress  `json:"address"`
+	Shipping *TestAddress `json:"shipping,omitempty" khadijah:",flatten"`
+	Billing  TestAddress  `json:"billing" khadijah:",serialize"`
+}
+
+func TestNestedStructs(t *testing.T) {
+	type Nested struct {
+		name     string
+		settings []k.KhadijahSetting
+		excludes []string
+		expected string
+		params   k.M
+	}
+
+	user := TestNestedUser{
+		TestAudit: TestAudit{CreatedBy: "mark"},
+		ID:        "1",
+		Address:   TestAddress{City: "Brooklyn", Zip: "11201"},
+		Shipping:  &TestAddress{City: "Queens", Zip: "11101"},
+		Billing:   TestAddress{City: "Harlem", Zip: "10026"},
+	}
+	billing := `{"city":"Harlem","zip":"10026"}`
+	tests := []Nested{
+		{
+			"struct value is the default",
+			[]k.KhadijahSetting{},
+			[]string{},
+			"CREATE (flava:user {created_by: $created_by, id: $id, address: $address, shipping_city: $shipping_city, shipping_zip: $shipping_zip, billing: $billing})",
+			k.M{"created_by": "mark", "address": user.Address, "shipping_city": "Queens", "billing": billing},
+		},
+		{
+			"flatten",
+			[]k.KhadijahSetting{k.SetStructMode(k.StructFlatten)},
+			[]string{},
+			"CREATE (flava:user {created_by: $created_by, id: $id, address_city: $address_city, address_zip: $address_zip, shipping_city: $shipping_city, shipping_zip: $shipping_zip, billing: $billing})",
+			k.M{"address_city": "Brooklyn", "address_zip": "11201", "billing": billing},
+		},
+		{
+			"excluding the parent excludes its fields",
+			[]k.KhadijahSetting{k.SetStructMode(k.StructFlatten)},
+			[]string{"address", "shipping_zip"},
+			"CREATE (flava:user {created_by: $created_by, id: $id, shipping_city: $shipping_city, billing: $billing})",
+			k.M{"address_city": "Brooklyn", "shipping_zip": "11101"},
+		},
+		{
+			"serialize",
+			[]k.KhadijahSetting{k.SetStructMode(k.StructSerialize)},
+			[]string{},
+			"CREATE (flava:user {created_by: $created_by, id: $id, address: $address, shipping_city: $shipping_city, shipping_zip: $shipping_zip, billing: $billing})",
+			k.M{"address": `{"city":"Brooklyn","zip":"11201"}`, "billing": billing},
+		},
+		{
+			"skip",
+			[]k.KhadijahSetting{k.SetStructMode(k.StructSkip)},
+			[]string{},
+			"CREATE (flava:user {created_by: $created_by, id: $id, shipping_city: $shipping_city, shipping_zip: $shipping_zip, billing: $billing})",
+			k.M{"billing": billing},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := k.New(test.settings...)
+			maxx := instance.CreateNode(user, userLabel, false, test.excludes...)
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			for key, value := range test.params {
+				if !reflect.DeepEqual(maxx.Params[key], value) {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", value, maxx.Params[key])
+				}
+			}
+		})
+	}
+
+	t.Run("nil embedded pointer is skipped", func(t *testing.T) {
+		type Embedded struct {
+			*TestAudit
+			ID string `json:"id"`
+		}
+
+		maxx := k.New().CreateNode(Embedded{ID: "1"}, userLabel, false)
+		expected := "CREATE (flava:user {id: $id})"
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+	})
+
+	t.Run("nil serialized struct is null", func(t *testing.T) {
+		type Serialized struct {
+			ID   string       `json:"id"`
+			Addr *TestAddress `json:"addr" khadijah:",serialize"`
+		}
+
+		maxx := k.New().CreateNode(Serialized{ID: "1"}, userLabel, false)
+		if value, ok := maxx.Params["addr"]; !ok || value != nil {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, value)
+		}
+
+		update := k.New(k.SetNullPolicy(k.NullRemove)).UpdateNode(Serialized{ID: "1"}, userLabel, false)
+		expected := "MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id REMOVE flava.addr"
+
+		if update.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, update.Query)
+		}
+	})
+
+	t.Run("hydrate", func(t *testing.T) {
+		instance := k.New(k.SetStructMode(k.StructFlatten))
+		record := k.M{
+			"created_by":    "mark",
+			"id":            "1",
+			"address_city":  "Brooklyn",
+			"address_zip":   "11201",
+			"shipping_city": "Queens",
+			"shipping_zip":  "11101",
+			"billing":       billing,
+		}
+		hydrated := TestNestedUser{}
+
+		if err := instance.Hydrate(record, &hydrated); err != nil {
+			t.Fatalf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		if !reflect.DeepEqual(hydrated, user) {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", user, hydrated)
+		}
+	})
+}
+
+type TestStatus int
+
+func (s TestStatus) String() string {
+	return [...]string{"inactive", "active"}[s]
+}
+
+type TestPoint struct {
+	X, Y int
+}
+
+type TestConverted struct {
+	ID       string         `json:"id"`
+	Nickname *string        `json:"nickname"`
+	Bio      *string        `json:"bio"`
+	Middle   sql.NullString `json:"middle"`
+	Status   TestStatus     `json:"status"`
+	Location TestPoint      `json:"location"`
+	Joined   time.Time      `json:"joined"`
+	Timeout  time.Duration  `json:"timeout"`
+}
+
+func TestConverters(t *testing.T) {
+	nickname := "max"
+	joined := time.Date(1993, time.August, 22, 0, 0, 0, 0, time.UTC)
+	user := TestConverted{
+		ID:       "1",
+		Nickname: &nickname,
+		Middle:   sql.NullString{String: "shaw", Valid: true},
+		Status:   TestStatus(1),
+		Location: TestPoint{1, 2},
+		Joined:   joined,
+		Timeout:  time.Second,
+	}
+	instance := k.New()
+	instance.RegisterConverter(reflect.TypeOf(TestPoint{}), func(value interface{}) (interface{}, error) {
+		point := value.(TestPoint)
+		return []int64{int64(point.X), int64(point.Y)}, nil
+	})
+	instance.RegisterHydrator(reflect.TypeOf(TestPoint{}), func(value interface{}) (interface{}, error) {
+		coords := value.([]interface{})
+		return TestPoint{int(coords[0].(int64)), int(coords[1].(int64))}, nil
+	})
+
+	t.Run("params", func(t *testing.T) {
+		maxx, err := instance.CreateNodeE(user, userLabel, false)
+		if err != nil {
+			t.Fatalf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		expected := k.M{
+			"id":       "1",
+			"nickname": "max",
+			"bio":      nil,
+			"middle":   "shaw",
+			"status":   "active",
+			"location": []int64{1, 2},
+			"joined":   joined,
+			"timeout":  int64(time.Second),
+		}
+
+		if !reflect.DeepEqual(maxx.Params, expected) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", expected, maxx.Params)
+		}
+	})
+
+	t.Run("converter errors", func(t *testing.T) {
+		failure := errors.New("bad point")
+		failing := k.New()
+		failing.RegisterConverter(reflect.TypeOf(TestPoint{}), func(value interface{}) (interface{}, error) {
+			return nil, failure
+		})
+
+		_, err := failing.CreateNodeE(user, userLabel, false)
+		if !errors.Is(err, failure) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", failure, err)
+		}
+	})
+
+	t.Run("hydrate", func(t *testing.T) {
+		record := k.M{
+			"id":       "1",
+			"nickname": "max",
+			"bio":      nil,
+			"middle":   "shaw",
+			"location": []interface{}{int64(1), int64(2)},
+			"joined":   joined,
+			"timeout":  int64(time.Second),
+		}
+		hydrated := TestConverted{}
+
+		if err := instance.Hydrate(record, &hydrated); err != nil {
+			t.Fatalf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		user.Status = 0
+		if !reflect.DeepEqual(hydrated, user) {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", user, hydrated)
+		}
+	})
+}
+
+type TestEmployee struct {
+	_    struct{} `khadijah:"Person:Employee,label"`
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+}
+
+func TestMultiLabel(t *testing.T) {
+	type MultiLabel struct {
+		name     string
+		query    func() (*k.Maxine, error)
+		expected string
+		err      error
+	}
+
+	instance := k.New()
+	employee := TestEmployee{ID: "1", Name: "max"}
+	tests := []MultiLabel{
+		{
+			"labels passed with the entity",
+			func() (*k.Maxine, error) {
+				return instance.CreateNodeE(k.WithLabels(userJ, "Person", "Employee", "Active"), nil, false)
+			},
+			"CREATE (flava:Person:Employee:Active {id: $id, name: $name, email: $email})",
+			nil,
+		},
+		{
+			"each label is quoted",
+			func() (*k.Maxine, error) {
+				return instance.MatchNodeE(k.WithLabels(userJ, "Person", "Team Lead"), nil, false)
+			},
+			"MATCH (flava:Person:`Team Lead`) WHERE id(flava) = $id",
+			nil,
+		},
+		{
+			"labels passed with the entity replace the struct's",
+			func() (*k.Maxine, error) {
+				return instance.MatchNodeE(k.WithLabels(employee, "Person"), nil, false)
+			},
+			"MATCH (flava:Person) WHERE id(flava) = $id",
+			nil,
+		},
+		{
+			"empty label part",
+			func() (*k.Maxine, error) {
+				return instance.MatchNodeE(k.WithLabels(userJ, "Person", "", "Employee"), nil, false)
+			},
+			"MATCH (flava:Person:Employee) WHERE id(flava) = $id",
+			k.ErrEmptyLabel,
+		},
+		{
+			"provided label is a single label",
+			func() (*k.Maxine, error) {
+				label := "User:Admin"
+				return instance.CreateNodeE(userJ, &label, false)
+			},
+			"CREATE (flava:`User:Admin` {id: $id, name: $name, email: $email})",
+			nil,
+		},
+		{
+			"provided label with an injection is a single label",
+			func() (*k.Maxine, error) {
+				label := "User) DETACH DELETE (flava"
+				return instance.MatchNodeE(userJ, &label, false)
+			},
+			"MATCH (flava:`User) DETACH DELETE (flava`) WHERE id(flava) = $id",
+			nil,
+		},
+		{
+			"struct declared labels",
+			func() (*k.Maxine, error) {
+				return instance.CreateNodeE(employee, nil, false)
+			},
+			"CREATE (flava:Person:Employee {id: $id, name: $name})",
+			nil,
+		},
+		{
+			"provided label overrides the struct's",
+			func() (*k.Maxine, error) {
+				return instance.UpdateNodeE(employee, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name",
+			nil,
+		},
+		{
+			"add and remove labels",
+			func() (*k.Maxine, error) {
+				return instance.UpdateLabelsE(employee, nil, []string{"Active", "Manager"}, []string{"Inactive"}, true)
+			},
+			"MATCH (flava:Person:Employee) WHERE id(flava) = $id SET flava:Active:Manager REMOVE flava:Inactive RETURN flava",
+			nil,
+		},
+		{
+			"remove labels with match",
+			func() (*k.Maxine, error) {
+				return instance.UpdateLabelsWithMatchE(employee, nil, k.M{"+v+.name": "name"}, nil, []string{"Employee"}, false)
+			},
+			"MATCH (flava:Person:Employee) WHERE flava.name = $name REMOVE flava:Employee",
+			nil,
+		},
+		{
+			"no labels to update",
+			func() (*k.Maxine, error) {
+				return instance.UpdateLabelsE(employee, nil, nil, nil, false)
+			},
+			"MATCH (flava:Person:Employee) WHERE id(flava) = $id",
+			k.ErrNoLabels,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.query()
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+		})
+	}
+}
+
+type UserRecord struct {
+	ID string `json:"id"`
+}
+
+func (u UserRecord) Label() string {
+	return "User"
+}
+
+type AdminRecord struct {
+	_  struct{} `khadijah:"Ignored,label"`
+	ID string   `json:"id"`
+}
+
+func (a AdminRecord) Labels() []string {
+	return []string{"User", "Admin"}
+}
+
+type Manages struct {
+	Since string `json:"since"`
+}
+
+func (m Manages) Label() string {
+	return "MANAGES"
+}
+
+func TestLabeler(t *testing.T) {
+	type Labeled struct {
+		name     string
+		query    func() *k.Maxine
+		expected string
+	}
+
+	instance := k.New()
+	tests := []Labeled{
+		{
+			"labeler",
+			func() *k.Maxine {
+				return instance.CreateNode(UserRecord{ID: "1"}, nil, false)
+			},
+			"CREATE (flava:User {id: $id})",
+		},
+		{
+			"labeler pointer",
+			func() *k.Maxine {
+				return instance.MatchNode(&UserRecord{ID: "1"}, nil, false)
+			},
+			"MATCH (flava:User) WHERE id(flava) = $id",
+		},
+		{
+			"multi labeler takes precedence over the tag",
+			func() *k.Maxine {
+				return instance.CreateNode(AdminRecord{ID: "1"}, nil, false)
+			},
+			"CREATE (flava:User:Admin {id: $id})",
+		},
+		{
+			"provided label takes precedence",
+			func() *k.Maxine {
+				return instance.CreateNode(UserRecord{ID: "1"}, userLabel, false)
+			},
+			"CREATE (flava:user {id: $id})",
+		},
+		{
+			"batch",
+			func() *k.Maxine {
+				return instance.CreateNodes([]UserRecord{{ID: "1"}}, nil, false)
+			},
+			"UNWIND $rows AS row CREATE (flava:User) SET flava = row",
+		},
+		{
+			"edge type",
+			func() *k.Maxine {
+				return instance.CreateEdge(UserRecord{ID: "1"}, AdminRecord{ID: "2"}, Manages{Since: "today"}, "out", nil, nil, nil, false)
+			},
+			"MATCH (start:User) WHERE id(start) = $start_id MATCH (end:User:Admin) WHERE id(end) = $end_id CREATE (start)-[flava:MANAGES {since: $since}]->(end)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.query()
+
+			if maxx.Err != nil {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, maxx.Err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+		})
+	}
+}
+
+func TestQueryBuilder(t *testing.T) {
+	type Builder struct {
+		name     string
+		query    func(instance *k.Khadijah) *k.Kyle
+		expected string
+		params   k.M
+		err      error
+	}
+
+	start := k.New(k.SetVariable("start"), k.SetParamPrefix("start_"))
+	tests := []Builder{
+		{
+			"strings and params",
+			func(instance *k.Khadijah) *k.Kyle {
+				return instance.Query().
+					Match("(flava:user)").
+					Where("flava.age > $age").
+					Params(k.M{"age": 30}).
+					Return("flava")
+			},
+			"MATCH (flava:user) WHERE flava.age > $age RETURN flava",
+			k.M{"age": 30},
+			nil,
+		},
+		{
+			"maxine fragments",
+			func(instance *k.Khadijah) *k.Kyle {
+				user := instance.MatchNode(userJ, userLabel, false)
+
+				return instance.Query().
+					Match("(flava:user)").
+					Where(user).
+					Set(instance.UpdateNode(userJ, userLabel, false)).
+					Return(user)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name, flava.email = $email RETURN flava",
+			k.M{"id": userJ.ID, "name": userJ.Name, "email": userJ.Email},
+			nil,
+		},
+		{
+			"maxine errors",
+			func(instance *k.Khadijah) *k.Kyle {
+				return instance.Query().Match(instance.NodeWithProperties(struct{}{}, userLabel))
+			},
+			"MATCH (flava:user )",
+			k.M{},
+			k.ErrNoProperties,
+		},
+		{
+			"clauses are joined",
+			func(instance *k.Khadijah) *k.Kyle {
+				user := instance.NodeWithProperties(userJ, userLabel)
+				friend := start.NodeWithProperties(userJ, userLabel)
+
+				return instance.Query().
+					Match(user).
+					OptionalMatch(fmt.Sprintf("(flava)-[:KNOWS]->%s", friend.Query)).
+					Params(friend.Params).
+					Where("flava.age > 30").
+					Where("start.age > 30").
+					With(user, friend).
+					Set("flava.seen = true").
+					Set("start.seen = true").
+					Merge("(flava)-[:SAW]->(start)").
+					Return(user, "start")
+			},
+			"MATCH (flava:user {id: $id, name: $name, email: $email}) OPTIONAL MATCH (flava)-[:KNOWS]->(start:user {id: $start_id, name: $start_name, email: $start_email}) WHERE flava.age > 30 AND start.age > 30 WITH flava, start SET flava.seen = true, start.seen = true MERGE (flava)-[:SAW]->(start) RETURN flava, start",
+			k.M{"id": userJ.ID, "start_id": userJ.ID},
+			nil,
+		},
+		{
+			"colliding params",
+			func(instance *k.Khadijah) *k.Kyle {
+				other := TestJsonUser{ID: "other", Name: userJ.Name, Email: userJ.Email}
+
+				return instance.Query().
+					Match(instance.NodeWithProperties(userJ, userLabel)).
+					Create(instance.NodeWithProperties(other, userLabel))
+			},
+			"MATCH (flava:user {id: $id, name: $name, email: $email}) CREATE (flava:user {id: $id, name: $name, email: $email})",
+			k.M{"id": userJ.ID},
+			k.ErrParamCollision,
+		},
+		{
+			"invalid fragment",
+			func(instance *k.Khadijah) *k.Kyle {
+				return instance.Query().Match(42).Return("flava")
+			},
+			"MATCH  RETURN flava",
+			k.M{},
+			k.ErrInvalidFragment,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.query(k.New()).BuildE()
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			for key, value := range test.params {
+				if maxx.Params[key] != value {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", value, maxx.Params[key])
+				}
+			}
+		})
+	}
+}
+
+func TestParamCollisions(t *testing.T) {
+	other := TestJsonUser{ID: "other", Name: userJ.Name, Email: "other@email"}
+
+	t.Run("merge params overwrites", func(t *testing.T) {
+		maxx := k.New().MatchNode(userJ, userLabel, false)
+		maxx.MergeParams(k.M{"id": "other"})
+
+		if maxx.Err != nil || maxx.Params["id"] != "other" {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v %v\n", "other", maxx.Params["id"], maxx.Err)
+		}
+
+		maxx.MergeParamsSafe(true, k.M{"id": "another"})
+
+		if maxx.Err != nil || maxx.Params["id"] != "other" {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v %v\n", "other", maxx.Params["id"], maxx.Err)
+		}
+	})
+
+	t.Run("merge params reports collisions", func(t *testing.T) {
+		maxx := k.New().MatchNode(userJ, userLabel, false)
+		err := maxx.MergeParamsE(false, k.M{"id": "other", "name": userJ.Name})
+
+		var collision *k.ParamCollisionError
+		if !errors.As(err, &collision) || !errors.Is(err, k.ErrParamCollision) {
+			t.Fatalf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrParamCollision, err)
+		}
+
+		if collision.Param != "id" || collision.Existing != userJ.ID || collision.Value != "other" {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%+v\n", "id", collision)
+		}
+
+		if maxx.Params["id"] != "other" {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", "other", maxx.Params["id"])
+		}
+	})
+
+	t.Run("returned variables don't merge params", func(t *testing.T) {
+		first := k.New(k.SetVariable("a")).MatchNode(userJ, userLabel, false)
+		second := k.New(k.SetVariable("b")).MatchNode(other, userLabel, false)
+		maxx, err := k.New().Query().
+			Match("(a:user)").
+			Match("(b:user)").
+			With(first, second).
+			Return(first, second).
+			BuildE()
+		expected := "MATCH (a:user) MATCH (b:user) WITH a, b RETURN a, b"
+
+		if err != nil {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+
+		if len(maxx.Params) != 0 {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.M{}, maxx.Params)
+		}
+	})
+
+	t.Run("rename param", func(t *testing.T) {
+		maxx := k.New().UpdateNodeWithMatch(TestDashedUser{ID: "1", FirstName: "mark"}, userLabel, k.M{"+v+.`first-name`": "first-name"}, false)
+		err := maxx.RenameParam("first-name", "first")
+		expected := "MATCH (flava:user) WHERE flava.`first-name` = $first SET flava.id = $id, flava.`first-name` = $first"
+
+		if err != nil {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+
+		if _, ok := maxx.Params["first-name"]; ok || maxx.Params["first"] != "mark" {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", "first", maxx.Params)
+		}
+	})
+
+	t.Run("rename param to an existing param", func(t *testing.T) {
+		maxx := k.New().MatchNode(userJ, userLabel, false)
+		err := maxx.RenameParam("id", "name")
+
+		if !errors.Is(err, k.ErrParamCollision) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrParamCollision, err)
+		}
+	})
+
+	t.Run("prefix params", func(t *testing.T) {
+		maxx := k.New().UpdateNodeWithMatch(userJ, userLabel, k.M{"+v+.name": "name", "id(+v+)": "id"}, false)
+		err := maxx.PrefixParams("id_")
+		expected := "MATCH (flava:user) WHERE flava.name = $id_name AND id(flava) = $id_id SET flava.id = $id_id, flava.name = $id_name, flava.email = $id_email"
+
+		if err != nil {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+
+		if maxx.Params["id_id"] != userJ.ID || len(maxx.Params) != 3 {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", userJ.ID, maxx.Params)
+		}
+	})
+
+	t.Run("builder strategies", func(t *testing.T) {
+		type Strategy struct {
+			name     string
+			strategy k.CollisionStrategy
+			expected string
+			params   k.M
+			err      error
+		}
+
+		tests := []Strategy{
+			{
+				"error",
+				k.CollisionError,
+				"MATCH (flava:user) WHERE id(flava) = $id MATCH (friend:user) WHERE id(friend) = $id RETURN flava, friend",
+				k.M{"id": userJ.ID},
+				k.ErrParamCollision,
+			},
+			{
+				"rename",
+				k.CollisionRename,
+				"MATCH (flava:user) WHERE id(flava) = $id MATCH (friend:user) WHERE id(friend) = $id_1 RETURN flava, friend",
+				k.M{"id": userJ.ID, "id_1": other.ID, "email_1": other.Email, "name": userJ.Name},
+				nil,
+			},
+			{
+				"prefix",
+				k.CollisionPrefix,
+				"MATCH (flava:user) WHERE id(flava) = $id MATCH (friend:user) WHERE id(friend) = $friend_id RETURN flava, friend",
+				k.M{"id": userJ.ID, "friend_id": other.ID, "friend_name": other.Name},
+				nil,
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				instance := k.New()
+				user := instance.MatchNode(userJ, userLabel, false)
+				friend := k.New(k.SetVariable("friend")).MatchNode(other, userLabel, false)
+				maxx, err := instance.Query().
+					OnCollision(test.strategy).
+					Match("(flava:user)").Where(user).
+					Match("(friend:user)").Where(friend).
+					Return(user, friend).
+					BuildE()
+
+				if !errors.Is(err, test.err) {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+				}
+
+				if maxx.Query != test.expected {
+					t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+				}
+
+				for key, value := range test.params {
+					if maxx.Params[key] != value {
+						t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", value, maxx.Params[key])
+					}
+				}
+
+				if friend.Params["id"] != other.ID {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", other.ID, friend.Params["id"])
+				}
+			})
+		}
+	})
+
+	t.Run("prefix with the same variable", func(t *testing.T) {
+		third := TestJsonUser{ID: "third", Name: "third", Email: "third@email"}
+		instance := k.New()
+		first := instance.MatchNode(userJ, userLabel, false)
+		second := instance.MatchNode(other, userLabel, false)
+		last := instance.MatchNode(third, userLabel, false)
+		maxx, err := instance.Query().
+			OnCollision(k.CollisionPrefix).
+			Match("(a:user)").Where(first).
+			Match("(b:user)").Where(second).
+			Match("(c:user)").Where(last).
+			BuildE()
+
+		if err != nil {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+
+		expected := "MATCH (a:user) WHERE id(flava) = $id MATCH (b:user) WHERE id(flava) = $flava_id MATCH (c:user) WHERE id(flava) = $flava_1_id"
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+
+		params := k.M{"id": userJ.ID, "flava_id": other.ID, "flava_email": other.Email, "flava_1_id": third.ID, "flava_1_name": third.Name}
+		for key, value := range params {
+			if maxx.Params[key] != value {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", value, maxx.Params[key])
+			}
+		}
+	})
+}
+
+type TestTeam struct {
+	Name string  `json:"name" khadijah:",key"`
+	Nick *string `json:"nick"`
+}
+
+type TestOmittedKeyTeam struct {
+	Name string `json:"name,omitempty" khadijah:",key"`
+}
+
+type TestNilKeyTeam struct {
+	Name *string `json:"name" khadijah:",key"`
+	Nick string  `json:"nick"`
+}
+
+func TestPathSuite(t *testing.T) {
+	type Path struct {
+		name     string
+		settings []k.KhadijahSetting
+		query    func(instance *k.Khadijah) (*k.Maxine, error)
+		expected string
+		params   k.M
+		err      error
+	}
+
+	team := TestTeam{Name: "flava"}
+	other := TestTeam{Name: "flavor"}
+	teamLabel := "Team"
+	repoLabel := "Repo"
+	ownsLabel := "OWNS"
+	memberOf := "MEMBER_OF"
+	path := []k.PathElement{
+		k.PathNode(userJ, userLabel),
+		k.PathEdgeWithMatch(follows, &memberOf, "out", k.M{"+v+.since": "since"}),
+		k.PathNode(team, &teamLabel),
+		k.PathEdge(nil, &ownsLabel, "out"),
+		k.PathNode(nil, &repoLabel),
+	}
+	tests := []Path{
+		{
+			"match uses keys and match clauses",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MatchPathE(path, true)
+			},
+			"MATCH (n0:user)-[r0:MEMBER_OF]->(n1:Team {name: $n1_name})-[r1:OWNS]->(n2:Repo) WHERE id(n0) = $n0_id AND r0.since = $r0_since RETURN n0, r0, n1, r1, n2",
+			k.M{"n0_id": userJ.ID, "r0_since": follows.Since, "n1_name": team.Name},
+			nil,
+		},
+		{
+			"omitempty keys are still matched",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MergePathE([]k.PathElement{k.PathNode(TestOmittedKeyTeam{}, &teamLabel)}, false)
+			},
+			"MERGE (n0:Team {name: $n0_name})",
+			k.M{"n0_name": ""},
+			nil,
+		},
+		{
+			"match with a node match clause",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MatchPathE([]k.PathElement{k.PathNodeWithMatch(userJ, userLabel, k.M{"+v+.email": "email"})}, false)
+			},
+			"MATCH (n0:user) WHERE n0.email = $n0_email",
+			k.M{"n0_email": userJ.Email},
+			nil,
+		},
+		{
+			"create",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreatePathE(path, false)
+			},
+			"CREATE (n0:user {id: $n0_id, name: $n0_name, email: $n0_email})-[r0:MEMBER_OF {since: $r0_since}]->(n1:Team {name: $n1_name, nick: $n1_nick})-[r1:OWNS]->(n2:Repo)",
+			k.M{"n0_email": userJ.Email},
+			nil,
+		},
+		{
+			"create with map properties",
+			[]k.KhadijahSetting{k.SetMapProperties(true)},
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.CreatePathE(path, false)
+			},
+			"CREATE (n0:user)-[r0:MEMBER_OF]->(n1:Team)-[r1:OWNS]->(n2:Repo) SET n0 = $n0_props, r0 = $r0_props, n1 = $n1_props",
+			k.M{},
+			nil,
+		},
+		{
+			"merge on keys and set the rest",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MergePathE([]k.PathElement{
+					k.PathNode(k.WithLabels(team, "Team", "Active"), nil),
+					k.PathEdge(follows, &memberOf, "in"),
+					k.PathNode(other, &teamLabel),
+				}, false)
+			},
+			"MERGE (n0:Team:Active {name: $n0_name})<-[r0:MEMBER_OF]-(n1:Team {name: $n1_name}) ON CREATE SET n0.nick = $n0_nick ON MATCH SET n0.nick = $n0_nick ON CREATE SET r0.since = $r0_since ON MATCH SET r0.since = $r0_since ON CREATE SET n1.nick = $n1_nick ON MATCH SET n1.nick = $n1_nick",
+			k.M{"n0_name": team.Name, "n1_name": other.Name},
+			nil,
+		},
+		{
+			"merge with map properties",
+			[]k.KhadijahSetting{k.SetMapProperties(true)},
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MergePathE([]k.PathElement{k.PathNode(team, &teamLabel)}, false)
+			},
+			"MERGE (n0:Team {name: $n0_name}) ON CREATE SET n0 += $n0_props ON MATCH SET n0 += $n0_onMatchProps",
+			k.M{"n0_name": team.Name},
+			nil,
+		},
+		{
+			"merge needs keys on nodes",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MergePathE([]k.PathElement{k.PathNode(userJ, userLabel)}, false)
+			},
+			"MERGE (n0:user) ON CREATE SET n0.id = $n0_id, n0.name = $n0_name, n0.email = $n0_email ON MATCH SET n0.id = $n0_id, n0.name = $n0_name, n0.email = $n0_email",
+			k.M{},
+			k.ErrNoKeyFields,
+		},
+		{
+			"elements must alternate",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MatchPathE([]k.PathElement{
+					k.PathNode(userJ, userLabel),
+					k.PathNode(team, &teamLabel),
+				}, false)
+			},
+			"MATCH (n0:user)(n1:Team {name: $n1_name}) WHERE id(n0) = $n0_id",
+			k.M{},
+			k.ErrInvalidPath,
+		},
+		{
+			"label is required without an entity",
+			nil,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.MatchPathE([]k.PathElement{
+					k.PathNode(nil, nil),
+				}, false)
+			},
+			"MATCH (n0:)",
+			k.M{},
+			k.ErrEmptyLabel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.query(k.New(test.settings...))
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			for key, value := range test.params {
+				if maxx.Params[key] != value {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", value, maxx.Params[key])
+				}
+			}
+		})
+	}
+}
+
+func TestTraverseSuite(t *testing.T) {
+	type Traverse struct {
+		name     string
+		query    func(instance *k.Khadijah) (*k.Maxine, error)
+		expected string
+		err      error
+	}
+
+	repoLabel := "Repo"
+	startMatch := "MATCH (start:user) WHERE id(start) = $start_id"
+	tests := []Traverse{
+		{
+			"bounded hops",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{Types: []string{"KNOWS"}, Direction: "out", MinHops: k.Hops(1), MaxHops: k.Hops(3)}, true)
+			},
+			startMatch + " MATCH (start)-[:KNOWS*1..3]->(end) RETURN end",
+			nil,
+		},
+		{
+			"several types and an end label",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{Types: []string{"OWNS", "MAINTAINS-NOW"}, Direction: "in", MaxHops: k.Hops(2), EndLabel: &repoLabel}, true)
+			},
+			startMatch + " MATCH (start)<-[:OWNS|`MAINTAINS-NOW`*..2]-(end:Repo) RETURN end",
+			nil,
+		},
+		{
+			"any type and hops",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{}, false)
+			},
+			startMatch + " MATCH (start)-[*]-(end)",
+			nil,
+		},
+		{
+			"exact and open ended hops",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				exact, _ := instance.TraverseE(userJ, userLabel, k.Traversal{MinHops: k.Hops(2), MaxHops: k.Hops(2)}, false)
+				maxx, err := instance.TraverseE(userJ, userLabel, k.Traversal{MinHops: k.Hops(2)}, false)
+				maxx.Query = exact.Query + " | " + maxx.Query
+
+				return maxx, err
+			},
+			startMatch + " MATCH (start)-[*2]-(end) | " + startMatch + " MATCH (start)-[*2..]-(end)",
+			nil,
+		},
+		{
+			"shortest path",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{Types: []string{"KNOWS"}, Direction: "out", MaxHops: k.Hops(5), EndLabels: []string{"user", "Admin"}, Mode: k.TraverseShortest}, true)
+			},
+			startMatch + " MATCH path = shortestPath((start)-[:KNOWS*..5]->(end:user:Admin)) RETURN path",
+			nil,
+		},
+		{
+			"all shortest paths with match",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseWithMatchE(userJ, userLabel, k.M{"+v+.email": "email"}, k.Traversal{Types: []string{"KNOWS"}, Mode: k.TraverseAllShortest}, true)
+			},
+			"MATCH (start:user) WHERE start.email = $start_email MATCH path = allShortestPaths((start)-[:KNOWS*]-(end)) RETURN path",
+			nil,
+		},
+		{
+			"empty type",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{Types: []string{""}}, false)
+			},
+			startMatch + " MATCH (start)-[:*]-(end)",
+			k.ErrEmptyLabel,
+		},
+		{
+			"zero minimum",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{MinHops: k.Hops(0), MaxHops: k.Hops(3)}, false)
+			},
+			startMatch + " MATCH (start)-[*0..3]-(end)",
+			nil,
+		},
+		{
+			"negative hops",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{MaxHops: k.Hops(-1)}, false)
+			},
+			startMatch + " MATCH (start)-[*]-(end)",
+			k.ErrInvalidHops,
+		},
+		{
+			"minimum above the maximum",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{MinHops: k.Hops(3), MaxHops: k.Hops(1)}, false)
+			},
+			startMatch + " MATCH (start)-[*]-(end)",
+			k.ErrInvalidHops,
+		},
+		{
+			"shortest path past 1 hop",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.TraverseE(userJ, userLabel, k.Traversal{MinHops: k.Hops(2), Mode: k.TraverseShortest}, true)
+			},
+			startMatch + " MATCH path = shortestPath((start)-[*]-(end)) RETURN path",
+			k.ErrInvalidHops,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.query(k.New())
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if test.err == nil && maxx.Params["start_id"] != userJ.ID {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", userJ.ID, maxx.Params["start_id"])
+			}
+		})
+	}
+}
+
+func TestSoftDeleteSuite(t *testing.T) {
+	type SoftDelete struct {
+		name     string
+		query    func(instance *k.Khadijah) *k.Maxine
+		expected string
+	}
+
+	instance := k.New(k.SetSoftDelete("deleted_at"), k.SetSoftDeleteLabel("Deleted"))
+	knowsLabel := "KNOWS"
+	tests := []SoftDelete{
+		{
+			"delete node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.DeleteNode(userJ, false)
+			},
+			"MATCH (flava) WHERE id(flava) = $id AND flava.deleted_at IS NULL SET flava.deleted_at = $now, flava:Deleted",
+		},
+		{
+			"detach delete node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.DetachDeleteNodeWithMatch(userJ, k.M{"+v+.email": "email"})
+			},
+			"MATCH (flava) WHERE flava.email = $email AND flava.deleted_at IS NULL SET flava.deleted_at = $now, flava:Deleted",
+		},
+		{
+			"delete nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.DeleteNodes([]TestJsonUser{userJ}, true)
+			},
+			"UNWIND $rows AS row MATCH (flava) WHERE id(flava) = row.id AND flava.deleted_at IS NULL SET flava.deleted_at = $now, flava:Deleted",
+		},
+		{
+			"delete edge",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.DeleteEdge(follows, knowsLabel, "out", k.M{"+v+.since": "since"})
+			},
+			"MATCH ()-[flava:KNOWS]->() WHERE flava.since = $since AND flava.deleted_at IS NULL SET flava.deleted_at = $now",
+		},
+		{
+			"delete edge between nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.DeleteEdgeBetween(userJ, userLabel, "out", userJ, userLabel, knowsLabel)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id AND start.deleted_at IS NULL MATCH (end:user) WHERE id(end) = $end_id AND end.deleted_at IS NULL MATCH (start)-[flava:KNOWS]->(end) WHERE flava.deleted_at IS NULL SET flava.deleted_at = $now",
+		},
+		{
+			"match node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.MatchNode(userJ, userLabel, true)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.deleted_at IS NULL RETURN flava",
+		},
+		{
+			"update node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(userJ, userLabel, false, "id", "email")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.deleted_at IS NULL SET flava.name = $name",
+		},
+		{
+			"update edge",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateEdgeWithMatches(userJ, userLabel, instance.MatchClause, "out", userJ, userLabel, instance.MatchClause, follows, &knowsLabel, nil, false)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id AND start.deleted_at IS NULL MATCH (end:user) WHERE id(end) = $end_id AND end.deleted_at IS NULL MATCH (start)-[flava:KNOWS]->(end) WHERE flava.deleted_at IS NULL SET flava.since = $since",
+		},
+		{
+			"traverse",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.Traverse(userJ, userLabel, k.Traversal{Types: []string{knowsLabel}, MaxHops: k.Hops(2)}, true)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id AND start.deleted_at IS NULL MATCH path = (start)-[:KNOWS*..2]-(end) WHERE all(n IN nodes(path) WHERE n.deleted_at IS NULL) AND all(r IN relationships(path) WHERE r.deleted_at IS NULL) RETURN end",
+		},
+		{
+			"match path",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.MatchPath([]k.PathElement{k.PathNode(nil, userLabel), k.PathEdge(nil, &knowsLabel, "out"), k.PathNode(nil, userLabel)}, false)
+			},
+			"MATCH (n0:user)-[r0:KNOWS]->(n1:user) WHERE n0.deleted_at IS NULL AND r0.deleted_at IS NULL AND n1.deleted_at IS NULL",
+		},
+		{
+			"include deleted",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.IncludeDeleted().MatchNode(userJ, userLabel, true)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id RETURN flava",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.query(instance)
+
+			if maxx.Err != nil {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, maxx.Err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if _, isTime := maxx.Params["now"].(time.Time); strings.Contains(test.expected, "$now") && !isTime {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", "time.Time", maxx.Params["now"])
+			}
+		})
+	}
+
+	t.Run("include deleted leaves the instance alone", func(t *testing.T) {
+		maxx := instance.MatchNode(userJ, userLabel, false)
+		expected := "MATCH (flava:user) WHERE id(flava) = $id AND flava.deleted_at IS NULL"
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+	})
+}
+
+type TestStamped struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at,omitempty" khadijah:",autoCreate"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" khadijah:",autoUpdate"`
+}
+
+type TestStampedEdge struct {
+	Since     string    `json:"since"`
+	CreatedAt time.Time `json:"created_at" khadijah:",autoCreate"`
+}
+
+func TestTimestamps(t *testing.T) {
+	type Timestamp struct {
+		name     string
+		query    func(instance *k.Khadijah) *k.Maxine
+		expected string
+	}
+
+	now := time.Date(1993, time.August, 22, 0, 0, 0, 0, time.UTC)
+	clock := k.SetClock(func() time.Time {
+		return now
+	})
+	stamped := TestStamped{ID: "1", Name: "max"}
+	edgeLabel := "KNOWS"
+	tests := []Timestamp{
+		{
+			"create with datetime",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateNode(stamped, userLabel, false)
+			},
+			"CREATE (flava:user {id: $id, name: $name, created_at: datetime(), updated_at: datetime()})",
+		},
+		{
+			"update never sets created_at",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(stamped, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name, flava.updated_at = datetime()",
+		},
+		{
+			"upsert only sets created_at on create",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpsertNode(stamped, userLabel, []string{"id"}, false)
+			},
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava.name = $name, flava.created_at = datetime(), flava.updated_at = datetime() ON MATCH SET flava.name = $name, flava.updated_at = datetime()",
+		},
+		{
+			"create edge",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateEdge(userJ, userJ, TestStampedEdge{Since: "today"}, "out", userLabel, userLabel, &edgeLabel, false)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id CREATE (start)-[flava:KNOWS {since: $since, created_at: datetime()}]->(end)",
+		},
+		{
+			"batch create with datetime",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateNodes([]TestStamped{stamped}, userLabel, false)
+			},
+			"UNWIND $rows AS row CREATE (flava:user) SET flava = row, flava.created_at = datetime(), flava.updated_at = datetime()",
+		},
+		{
+			"batch update with datetime",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNodes([]TestStamped{stamped}, userLabel, false)
+			},
+			"UNWIND $rows AS row MATCH (flava:user) WHERE id(flava) = row.match.id SET flava += row.props, flava.updated_at = datetime()",
+		},
+		{
+			"node patterns never match timestamps",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.NodeWithProperties(stamped, userLabel)
+			},
+			"(flava:user {id: $id, name: $name})",
+		},
+		{
+			"path patterns never match timestamps",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.MatchPath([]k.PathElement{k.PathNode(stamped, userLabel)}, false)
+			},
+			"MATCH (n0:user) WHERE id(n0) = $n0_id",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.query(k.New())
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if _, ok := maxx.Params["created_at"]; ok {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, maxx.Params["created_at"])
+			}
+		})
+	}
+
+	t.Run("clock", func(t *testing.T) {
+		instance := k.New(clock)
+		maxx := instance.CreateNode(stamped, userLabel, false)
+		expected := "CREATE (flava:user {id: $id, name: $name, created_at: $created_at, updated_at: $updated_at})"
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+
+		if maxx.Params["created_at"] != now || maxx.Params["updated_at"] != now {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", now, maxx.Params)
+		}
+
+		update := instance.UpdateNode(stamped, userLabel, false)
+		expected = "MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name, flava.updated_at = $updated_at"
+
+		if update.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, update.Query)
+		}
+
+		node := instance.NodeWithProperties(stamped, userLabel)
+		expected = "(flava:user {id: $id, name: $name})"
+
+		if node.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, node.Query)
+		}
+	})
+
+	t.Run("batches leave datetime timestamps out of the rows", func(t *testing.T) {
+		maxx := k.New().CreateNodes([]TestStamped{stamped}, userLabel, false)
+		row := maxx.Params["rows"].([]interface{})[0].(map[string]interface{})
+
+		if _, ok := row["created_at"]; ok {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", "no created_at", row)
+		}
+
+		if _, ok := row["updated_at"]; ok {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", "no updated_at", row)
+		}
+	})
+
+	t.Run("batches use the clock", func(t *testing.T) {
+		maxx := k.New(clock).UpdateNodes([]TestStamped{stamped}, userLabel, false)
+		rows := maxx.Params["rows"].([]interface{})
+		props := rows[0].(map[string]interface{})["props"].(map[string]interface{})
+
+		if _, ok := props["created_at"]; ok || props["updated_at"] != now {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", now, props)
+		}
+	})
+
+	t.Run("soft deletes use the clock", func(t *testing.T) {
+		maxx := k.New(clock, k.SetSoftDelete("deleted_at")).DeleteNode(stamped, false)
+
+		if maxx.Params["now"] != now {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", now, maxx.Params["now"])
+		}
+	})
+}
+
+type TestVersioned struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version" khadijah:",version"`
+}
+
+type TestOmittedVersion struct {
+	ID      string `json:"id"`
+	Version int    `json:"version,omitempty" khadijah:",version"`
+}
+
+type TestVersionedEdge struct {
+	Since   string `json:"since"`
+	Version int    `json:"version" khadijah:",version"`
+}
+
+func TestOptimisticLocking(t *testing.T) {
+	type Locking struct {
+		name      string
+		query     func(instance *k.Khadijah) *k.Maxine
+		expected  string
+		versioned bool
+	}
+
+	versioned := TestVersioned{ID: "1", Name: "max", Version: 3}
+	edgeLabel := "KNOWS"
+	tests := []Locking{
+		{
+			"update node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(versioned, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.version = $version SET flava.id = $id, flava.name = $name, flava.version = flava.version + 1 RETURN flava",
+			true,
+		},
+		{
+			"update node with match",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNodeWithMatch(versioned, userLabel, k.M{"+v+.name": "name"}, true, "id")
+			},
+			"MATCH (flava:user) WHERE flava.name = $name AND flava.version = $version SET flava.name = $name, flava.version = flava.version + 1 RETURN flava",
+			true,
+		},
+		{
+			"omitempty zero version is still checked",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(TestOmittedVersion{ID: "1"}, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.version = $version SET flava.id = $id, flava.version = flava.version + 1 RETURN flava",
+			true,
+		},
+		{
+			"excluded version is not checked",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(versioned, userLabel, false, "version")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name",
+			false,
+		},
+		{
+			"update nodes",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNodes([]TestVersioned{versioned}, userLabel, false)
+			},
+			"UNWIND $rows AS row MATCH (flava:user) WHERE id(flava) = row.match.id AND flava.version = row.match.version SET flava += row.props, flava.version = flava.version + 1 RETURN flava",
+			true,
+		},
+		{
+			"update edge",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateEdgeWithMatches(userJ, userLabel, instance.MatchClause, "out", userJ, userLabel, instance.MatchClause, TestVersionedEdge{Since: "today", Version: 1}, &edgeLabel, nil, false)
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:KNOWS]->(end) WHERE flava.version = $version SET flava.since = $since, flava.version = flava.version + 1 RETURN start, flava, end",
+			true,
+		},
+		{
+			"create sets the version",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateNode(versioned, userLabel, false)
+			},
+			"CREATE (flava:user {id: $id, name: $name, version: $version})",
+			false,
+		},
+		{
+			"upsert sets the version on create and increments it on match",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpsertNode(versioned, userLabel, []string{"id"}, false)
+			},
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava.name = $name, flava.version = $version ON MATCH SET flava.name = $name, flava.version = flava.version + 1",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.query(k.New())
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if maxx.Versioned != test.versioned {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.versioned, maxx.Versioned)
+			}
+		})
+	}
+}
+
+type TestDiffUser struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Email    string  `json:"email,omitempty"`
+	Nickname *string `json:"nickname"`
+	Age      int     `json:"age"`
+}
+
+func TestUpdateNodeDiff(t *testing.T) {
+	type Diff struct {
+		name     string
+		query    func(instance *k.Khadijah) (*k.Maxine, error)
+		expected string
+		params   k.M
+		err      error
+	}
+
+	nickname := "maxie"
+	before := TestDiffUser{ID: "1", Name: "max", Email: "max@flavor.com", Nickname: &nickname, Age: 30}
+	renamed := before
+	renamed.Name = "maxine"
+	cleared := before
+	cleared.Email = ""
+	cleared.Nickname = nil
+	tests := []Diff{
+		{
+			"changed property",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeDiffE(before, renamed, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.name = $name",
+			k.M{"name": "maxine"},
+			nil,
+		},
+		{
+			"omitted and nil properties are removed",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeDiffE(before, cleared, userLabel, true)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id REMOVE flava.nickname, flava.email RETURN flava",
+			k.M{"id": "1"},
+			nil,
+		},
+		{
+			"with match",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeDiffWithMatchE(&before, &renamed, userLabel, k.M{"+v+.email": "email"}, false)
+			},
+			"MATCH (flava:user) WHERE flava.email = $email SET flava.name = $name",
+			k.M{"email": "max@flavor.com"},
+			nil,
+		},
+		{
+			"versioned",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeDiffE(TestVersioned{ID: "1", Name: "max", Version: 2}, TestVersioned{ID: "1", Name: "maxine", Version: 2}, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.version = $version SET flava.name = $name, flava.version = flava.version + 1 RETURN flava",
+			k.M{"version": 2},
+			nil,
+		},
+		{
+			"no changes",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeDiffE(before, before, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id",
+			nil,
+			k.ErrNoChanges,
+		},
+		{
+			"mismatched entities",
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeDiffE(before, TestVersioned{ID: "1"}, userLabel, false)
+			},
+			"",
+			nil,
+			k.ErrMismatchedEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.query(k.New())
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if test.expected != "" && maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			for key, value := range test.params {
+				if maxx.Params[key] != value {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", value, maxx.Params[key])
+				}
+			}
+		})
+	}
+}
+
+func TestNullPolicy(t *testing.T) {
+	type Policy struct {
+		name     string
+		policy   k.NullPolicy
+		query    func(instance *k.Khadijah) (*k.Maxine, error)
+		expected string
+		err      error
+	}
+
+	user := TestDiffUser{ID: "1", Name: "max"}
+	versioned := TestVersioned{ID: "1", Name: "max", Version: 2}
+	edgeLabel := "KNOWS"
+	tests := []Policy{
+		{
+			"set is the default",
+			k.NullSet,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeE(user, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name, flava.nickname = $nickname, flava.age = $age",
+			nil,
+		},
+		{
+			"skip",
+			k.NullSkip,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeE(user, userLabel, false)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name, flava.age = $age",
+			nil,
+		},
+		{
+			"remove",
+			k.NullRemove,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateNodeE(user, userLabel, true)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava.id = $id, flava.name = $name, flava.age = $age REMOVE flava.nickname RETURN flava",
+			nil,
+		},
+		{
+			"remove on upsert",
+			k.NullRemove,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpsertNodeE(user, userLabel, []string{"id"}, false)
+			},
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava.name = $name, flava.age = $age ON MATCH SET flava.name = $name, flava.age = $age REMOVE flava.nickname",
+			nil,
+		},
+		{
+			"remove on edge update",
+			k.NullRemove,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.UpdateEdgeWithMatchesE(userJ, userLabel, instance.MatchClause, "out", userJ, userLabel, instance.MatchClause, user, &edgeLabel, nil, false, "id")
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:KNOWS]->(end) SET flava.name = $name, flava.age = $age REMOVE flava.nickname",
+			nil,
+		},
+		{
+			"remove properties",
+			k.NullSet,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.RemovePropertiesE(user, userLabel, "nickname", "first-name")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id REMOVE flava.nickname, flava.`first-name`",
+			nil,
+		},
+		{
+			"remove properties with match",
+			k.NullSet,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.RemovePropertiesWithMatchE(user, userLabel, k.M{"+v+.name": "name"}, "age")
+			},
+			"MATCH (flava:user) WHERE flava.name = $name REMOVE flava.age",
+			nil,
+		},
+		{
+			"remove properties from a versioned node",
+			k.NullSet,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.RemovePropertiesE(versioned, userLabel, "name")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.version = $version SET flava.version = flava.version + 1 REMOVE flava.name RETURN flava",
+			nil,
+		},
+		{
+			"remove nothing",
+			k.NullSet,
+			func(instance *k.Khadijah) (*k.Maxine, error) {
+				return instance.RemovePropertiesE(user, userLabel)
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id",
+			k.ErrNoRemovals,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx, err := test.query(k.New(k.SetNullPolicy(test.policy)))
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.err, err)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+		})
+	}
+
+	t.Run("skip leaves nulls out of batch updates", func(t *testing.T) {
+		maxx := k.New(k.SetNullPolicy(k.NullSkip)).UpdateNodes([]TestDiffUser{user}, userLabel, false)
+		rows := maxx.Params[k.RowsParam].([]interface{})
+		props := rows[0].(map[string]interface{})["props"].(map[string]interface{})
+
+		if _, ok := props["nickname"]; ok {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", "no nickname", props)
+		}
+	})
+
+	t.Run("kyle removes", func(t *testing.T) {
+		instance := k.New(k.SetNullPolicy(k.NullRemove))
+		update := instance.UpdateNode(user, userLabel, false)
+		maxx := instance.Query().Match("(flava:user)").Remove(update).Build()
+		expected := "MATCH (flava:user) REMOVE flava.nickname"
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+	})
+}
+
+func TestMapProperties(t *testing.T) {
+	type Mapped struct {
+		name     string
+		query    func(instance *k.Khadijah) *k.Maxine
+		expected string
+		props    map[string]interface{}
+		params   []string
+	}
+
+	user := TestDiffUser{ID: "1", Name: "max", Age: 30}
+	edgeLabel := "KNOWS"
+	tests := []Mapped{
+		{
+			"create node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateNode(user, userLabel, true)
+			},
+			"CREATE (flava:user) SET flava = $props RETURN flava",
+			map[string]interface{}{"id": "1", "name": "max", "nickname": nil, "age": 30},
+			[]string{"props"},
+		},
+		{
+			"update node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(user, userLabel, false, "id")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava += $props",
+			map[string]interface{}{"name": "max", "nickname": nil, "age": 30},
+			[]string{"id", "props"},
+		},
+		{
+			"update node skips nulls",
+			func(instance *k.Khadijah) *k.Maxine {
+				instance.Apply(k.SetNullPolicy(k.NullSkip))
+
+				return instance.UpdateNode(user, userLabel, false, "id")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava += $props",
+			map[string]interface{}{"name": "max", "age": 30},
+			[]string{"id", "props"},
+		},
+		{
+			"expressions and versions follow the map",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateNode(TestVersioned{ID: "1", Name: "max", Version: 2}, userLabel, false, "id")
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.version = $version SET flava += $props, flava.version = flava.version + 1 RETURN flava",
+			map[string]interface{}{"name": "max"},
+			[]string{"id", "props", "version"},
+		},
+		{
+			"timestamps follow the map",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateNode(TestStamped{ID: "1", Name: "max"}, userLabel, false)
+			},
+			"CREATE (flava:user) SET flava = $props, flava.created_at = datetime(), flava.updated_at = datetime()",
+			map[string]interface{}{"id": "1", "name": "max"},
+			[]string{"props"},
+		},
+		{
+			"create edge",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.CreateEdge(userJ, userJ, Rated{Score: 5}, "out", userLabel, userLabel, &edgeLabel, false, "id")
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id CREATE (start)-[flava:KNOWS]->(end) SET flava = $props",
+			map[string]interface{}{"score": 5},
+			[]string{"end_id", "props", "start_id"},
+		},
+		{
+			"update edge",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpdateEdgeWithMatches(userJ, userLabel, instance.MatchClause, "out", userJ, userLabel, instance.MatchClause, Rated{Score: 5}, &edgeLabel, nil, false, "id")
+			},
+			"MATCH (start:user) WHERE id(start) = $start_id MATCH (end:user) WHERE id(end) = $end_id MATCH (start)-[flava:KNOWS]->(end) SET flava += $props",
+			map[string]interface{}{"score": 5},
+			[]string{"end_id", "props", "start_id"},
+		},
+		{
+			"upsert node",
+			func(instance *k.Khadijah) *k.Maxine {
+				return instance.UpsertNode(user, userLabel, []string{"id"}, false)
+			},
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava += $props ON MATCH SET flava += $onMatchProps",
+			map[string]interface{}{"id": "1", "name": "max", "nickname": nil, "age": 30},
+			[]string{"id", "onMatchProps", "props"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maxx := test.query(k.New(k.SetMapProperties(true)))
+			params := []string{}
+
+			// only the map params and the ones the query uses are sent
+			for _, pair := range maxx.Params.Pairs() {
+				params = append(params, pair.Key)
+			}
+
+			if !reflect.DeepEqual(params, test.params) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.params, params)
+			}
+
+			if maxx.Query != test.expected {
+				t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+			}
+
+			if !reflect.DeepEqual(maxx.Params[k.PropsParam], test.props) {
+				t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", test.props, maxx.Params[k.PropsParam])
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	type Normalized struct {
+		name     string
+		queries  []func(instance *k.Khadijah) *k.Maxine
+		expected string
+	}
+
+	nickname := "maxie"
+	user := TestDiffUser{ID: "1", Name: "max", Age: 30}
+	full := TestDiffUser{ID: "1", Name: "max", Email: "max@flavor.com", Nickname: &nickname, Age: 30}
+	tests := []Normalized{
+		{
+			"create ignores excludes and omitted fields",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.CreateNode(user, userLabel, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.CreateNode(full, userLabel, false, "age")
+				},
+			},
+			"CREATE (flava:user) SET flava = $props",
+		},
+		{
+			"update ignores match clause order",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpdateNodeWithMatch(full, userLabel, k.OM{{"+v+.name", "name"}, {"+v+.email", "email"}}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpdateNodeWithMatch(full, userLabel, k.OM{{"+v+.email", "email"}, {"+v+.name", "name"}}, false, "nickname")
+				},
+			},
+			"MATCH (flava:user) WHERE flava.email = $email AND flava.name = $name SET flava += $props",
+		},
+		{
+			"upsert",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpsertNode(user, userLabel, []string{"id"}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpsertNode(full, userLabel, []string{"id"}, false, "name")
+				},
+			},
+			"MERGE (flava:user {id: $id}) ON CREATE SET flava += $props ON MATCH SET flava += $onMatchProps",
+		},
+		{
+			"diff",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpdateNodeDiff(user, full, userLabel, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpdateNodeDiff(full, user, userLabel, false)
+				},
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava += $props",
+		},
+		{
+			"create path",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.CreatePath([]k.PathElement{k.PathNode(user, userLabel)}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.CreatePath([]k.PathElement{k.PathNode(full, userLabel)}, false)
+				},
+			},
+			"CREATE (n0:user) SET n0 = $n0_props",
+		},
+		{
+			"merge path",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MergePath([]k.PathElement{k.PathNode(TestTeam{Name: "flava"}, userLabel)}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MergePath([]k.PathElement{k.PathNode(TestTeam{Name: "flava", Nick: &nickname}, userLabel)}, false)
+				},
+			},
+			"MERGE (n0:user {name: $n0_name}) ON CREATE SET n0 += $n0_props ON MATCH SET n0 += $n0_onMatchProps",
+		},
+		{
+			"match path",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MatchPath([]k.PathElement{k.PathNode(user, userLabel)}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MatchPath([]k.PathElement{k.PathNode(full, userLabel)}, false)
+				},
+			},
+			"MATCH (n0:user) WHERE id(n0) = $n0_id",
+		},
+		{
+			"update with a zero omitempty version",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpdateNode(TestOmittedVersion{ID: "1"}, userLabel, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.UpdateNode(TestOmittedVersion{ID: "1", Version: 3}, userLabel, false)
+				},
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id AND flava.version = $version SET flava += $props, flava.version = flava.version + 1 RETURN flava",
+		},
+		{
+			"update with nil values that are removed",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					instance.Apply(k.SetNullPolicy(k.NullRemove))
+
+					return instance.UpdateNode(user, userLabel, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					instance.Apply(k.SetNullPolicy(k.NullRemove))
+
+					return instance.UpdateNode(full, userLabel, false)
+				},
+			},
+			"MATCH (flava:user) WHERE id(flava) = $id SET flava += $props",
+		},
+		{
+			"match path with a nil key",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MatchPath([]k.PathElement{k.PathNode(TestNilKeyTeam{}, userLabel)}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MatchPath([]k.PathElement{k.PathNode(TestNilKeyTeam{Name: &nickname}, userLabel)}, false)
+				},
+			},
+			"MATCH (n0:user {name: $n0_name})",
+		},
+		{
+			"merge path with a nil key",
+			[]func(instance *k.Khadijah) *k.Maxine{
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MergePath([]k.PathElement{k.PathNode(TestNilKeyTeam{Nick: "flava"}, userLabel)}, false)
+				},
+				func(instance *k.Khadijah) *k.Maxine {
+					return instance.MergePath([]k.PathElement{k.PathNode(TestNilKeyTeam{Name: &nickname}, userLabel)}, false)
+				},
+			},
+			"MERGE (n0:user {name: $n0_name}) ON CREATE SET n0 += $n0_props ON MATCH SET n0 += $n0_onMatchProps",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fingerprints := map[string]bool{}
+
+			for _, query := range test.queries {
+				maxx := query(k.New(k.SetNormalize(true)))
+
+				if maxx.Err != nil {
+					t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, maxx.Err)
+				}
+
+				if maxx.Query != test.expected {
+					t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", test.expected, maxx.Query)
+				}
+
+				fingerprints[maxx.Fingerprint()] = true
+			}
+
+			if len(fingerprints) != 1 {
+				t.Errorf("\nexpected: \n\t%d \nbut got: \n\t%v\n", 1, len(fingerprints))
+			}
+		})
+	}
+
+	t.Run("node with properties keeps its pattern", func(t *testing.T) {
+		instance := k.New(k.SetNormalize(true))
+		node := instance.NodeWithProperties(full, userLabel)
+		maxx := instance.Query().Create(node).Build()
+		expected := "CREATE (flava:user {id: $id, name: $name, email: $email, nickname: $nickname, age: $age})"
+
+		if maxx.Query != expected {
+			t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+		}
+	})
+
+	t.Run("diff removes with nulls", func(t *testing.T) {
+		maxx := k.New(k.SetNormalize(true)).UpdateNodeDiff(full, user, userLabel, false)
+		expected := map[string]interface{}{"email": nil, "nickname": nil}
+
+		if !reflect.DeepEqual(maxx.Params[k.PropsParam], expected) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", expected, maxx.Params[k.PropsParam])
+		}
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	spaced := &k.Maxine{Query: " MATCH (flava:user)\n\tWHERE id(flava) = $id  RETURN flava "}
+	compact := &k.Maxine{Query: "MATCH (flava:user) WHERE id(flava) = $id RETURN flava", Params: k.M{"id": "1"}}
+	other := &k.Maxine{Query: "MATCH (flava:user) WHERE id(flava) = $id"}
+
+	if spaced.Fingerprint() != compact.Fingerprint() {
+		t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", compact.Fingerprint(), spaced.Fingerprint())
+	}
+
+	if other.Fingerprint() == compact.Fingerprint() {
+		t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", "a different fingerprint", other.Fingerprint())
+	}
+
+	if len(compact.Fingerprint()) != 16 {
+		t.Errorf("\nexpected: \n\t%d \nbut got: \n\t%v\n", 16, len(compact.Fingerprint()))
+	}
+}
+
+type TestConvertedPointers struct {
+	Joined  *time.Time     `json:"joined"`
+	Timeout *time.Duration `json:"timeout"`
+	Left    *time.Time     `json:"left"`
+}
+
+func TestConvertPointers(t *testing.T) {
+	type Pointer struct {
+		name     string
+		param    string
+		expected interface{}
+	}
+
+	joined := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	timeout := time.Hour
+	user := TestConvertedPointers{Joined: &joined, Timeout: &timeout}
+	tests := []Pointer{
+		{"time pointer", "joined", joined},
+		{"duration pointer", "timeout", int64(time.Hour)},
+		{"nil pointer", "left", nil},
+	}
+	maxx, err := k.New().CreateNodeE(user, userLabel, false)
+	if err != nil {
+		t.Fatalf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, ok := maxx.Params[test.param]
+			if !ok || !reflect.DeepEqual(value, test.expected) {
+				t.Errorf("\nexpected: \n\t%v (%T) \nbut got: \n\t%v (%T)\n", test.expected, test.expected, value, value)
+			}
+		})
+	}
+}
+
+type TestStringerAddress struct {
+	City string `json:"city"`
+}
+
+func (a TestStringerAddress) String() string {
+	return "c"
+}
+
+type TestStringerUser struct {
+	ID         string               `json:"id"`
+	Flat       TestStringerAddress  `json:"flat" khadijah:",flatten"`
+	Serialized *TestStringerAddress `json:"serialized" khadijah:",serialize"`
+	Plain      TestStringerAddress  `json:"plain"`
+}
+
+func TestConvertStructOptions(t *testing.T) {
+	user := TestStringerUser{
+		ID:         "1",
+		Flat:       TestStringerAddress{City: "Brooklyn"},
+		Serialized: &TestStringerAddress{City: "Queens"},
+		Plain:      TestStringerAddress{City: "Harlem"},
+	}
+	maxx, err := k.New().CreateNodeE(user, userLabel, false)
+	if err != nil {
+		t.Fatalf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+	}
+
+	expected := "CREATE (flava:user {id: $id, flat_city: $flat_city, serialized: $serialized, plain: $plain})"
+	if maxx.Query != expected {
+		t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", expected, maxx.Query)
+	}
+
+	params := k.M{"id": "1", "flat_city": "Brooklyn", "serialized": `{"city":"Queens"}`, "plain": "c"}
+	if !reflect.DeepEqual(maxx.Params, params) {
+		t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", params, maxx.Params)
+	}
 }
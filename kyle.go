@@ -0,0 +1,303 @@
+package khadijah
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// the keywords of the clauses that Kyle builds
+const (
+	KeywordMatch         = "MATCH"
+	KeywordOptionalMatch = "OPTIONAL MATCH"
+	KeywordWhere         = "WHERE"
+	KeywordWith          = "WITH"
+	KeywordSet           = "SET"
+	KeywordRemove        = "REMOVE"
+	KeywordCreate        = "CREATE"
+	KeywordMerge         = "MERGE"
+	KeywordReturn        = "RETURN"
+)
+
+// CollisionStrategy defines what Kyle does when a Maxine's param is already
+// used with a different value
+type CollisionStrategy int
+
+const (
+	// CollisionError keeps the first value and reports an ErrParamCollision
+	CollisionError CollisionStrategy = iota
+
+	// CollisionRename renames the colliding params, and their placeholders,
+	// with a numbered suffix: $id becomes $id_1
+	CollisionRename
+
+	// CollisionPrefix prefixes all of the params, and their placeholders,
+	// with the Maxine's variable: $id becomes $friend_id, or $friend_1_id
+	// when another friend already took that prefix
+	CollisionPrefix
+)
+
+// newKyle creates a Kyle whose built Maxine shares rootMaxx's settings
+func newKyle(rootMaxx *Maxine) *Kyle {
+	return &Kyle{
+		maxx: rootMaxx.derive(rootMaxx.Variable, rootMaxx.ParamPefix, rootMaxx.DefaultMatchClause),
+	}
+}
+
+// Kyle composes queries from strings and the fragments found in Maxine
+// instances. The Params of every Maxine used for its pattern, condition, or
+// assignments are merged into the built query, a param that is used twice with
+// different values is an error
+//
+//	user := instance.NodeWithProperties(mark, &label)
+//	maxx, err := instance.Query().Match(user).Set(update).Return(user).BuildE()
+type Kyle struct {
+	maxx       *Maxine
+	clauses    []kyleClause
+	collisions CollisionStrategy
+}
+
+type kyleClause struct {
+	keyword   string
+	fragments []string
+}
+
+// Match adds a MATCH clause. A Maxine's Query is used as the pattern, like
+// the one built by NodeWithProperties
+func (k *Kyle) Match(pattern interface{}) *Kyle {
+	return k.add(KeywordMatch, "", k.pattern(pattern))
+}
+
+// OptionalMatch adds an OPTIONAL MATCH clause, it works like Match
+func (k *Kyle) OptionalMatch(pattern interface{}) *Kyle {
+	return k.add(KeywordOptionalMatch, "", k.pattern(pattern))
+}
+
+// Where adds a WHERE clause. A Maxine's MatchClause is used as the condition.
+// Consecutive calls are joined with AND
+func (k *Kyle) Where(condition interface{}) *Kyle {
+	return k.add(KeywordWhere, " AND ", k.fragment(condition, func(maxx *Maxine) string {
+		return maxx.MatchClause
+	}))
+}
+
+// With adds a WITH clause. A Maxine's Variable is used as the item
+func (k *Kyle) With(items ...interface{}) *Kyle {
+	return k.add(KeywordWith, ", ", k.variables(items)...)
+}
+
+// Set adds a SET clause. A Maxine's SetQuery is used as the assignments.
+// Consecutive calls are joined with commas
+func (k *Kyle) Set(assignments interface{}) *Kyle {
+	return k.add(KeywordSet, ", ", k.fragment(assignments, func(maxx *Maxine) string {
+		return maxx.SetQuery
+	}))
+}
+
+// Remove adds a REMOVE clause. A Maxine's RemoveQuery is used as the items.
+// Consecutive calls are joined with commas
+func (k *Kyle) Remove(items interface{}) *Kyle {
+	return k.add(KeywordRemove, ", ", k.fragment(items, func(maxx *Maxine) string {
+		return maxx.RemoveQuery
+	}))
+}
+
+// Create adds a CREATE clause, it works like Match
+func (k *Kyle) Create(pattern interface{}) *Kyle {
+	return k.add(KeywordCreate, "", k.pattern(pattern))
+}
+
+// Merge adds a MERGE clause, it works like Match
+func (k *Kyle) Merge(pattern interface{}) *Kyle {
+	return k.add(KeywordMerge, "", k.pattern(pattern))
+}
+
+// Return adds a RETURN clause. A Maxine's Variable is used as the item
+func (k *Kyle) Return(items ...interface{}) *Kyle {
+	return k.add(KeywordReturn, ", ", k.variables(items)...)
+}
+
+// Raw adds the fragment to the query as is
+func (k *Kyle) Raw(fragment string) *Kyle {
+	return k.add("", "", fragment)
+}
+
+// OnCollision sets how the params of the Maxine fragments that collide with
+// the query's params are handled, CollisionError is the default
+func (k *Kyle) OnCollision(strategy CollisionStrategy) *Kyle {
+	k.collisions = strategy
+
+	return k
+}
+
+// Params merges the params into the query's params. They follow the same
+// collision rules as a Maxine's Params
+func (k *Kyle) Params(params M) *Kyle {
+	k.merge(params)
+
+	return k
+}
+
+// Build returns a Maxine that holds the query, its params, and the first error found
+func (k *Kyle) Build() *Maxine {
+	parts := make([]string, 0, len(k.clauses))
+
+	for _, clause := range k.clauses {
+		part := strings.Join(clause.fragments, "")
+		if clause.keyword != "" {
+			part = clause.keyword + " " + part
+		}
+
+		parts = append(parts, part)
+	}
+
+	k.maxx.Query = strings.Join(parts, " ")
+
+	return k.maxx
+}
+
+// BuildE works like Build but returns any error found
+func (k *Kyle) BuildE() (*Maxine, error) {
+	maxx := k.Build()
+
+	return maxx, maxx.Err
+}
+
+// add appends the fragments to the last clause when it uses the same keyword
+// and a separator, otherwise a new clause is started
+func (k *Kyle) add(keyword, separator string, fragments ...string) *Kyle {
+	if len(fragments) == 0 {
+		return k
+	}
+
+	last := len(k.clauses) - 1
+	if separator != "" && last >= 0 && k.clauses[last].keyword == keyword {
+		k.clauses[last].fragments = append(k.clauses[last].fragments, separator+strings.Join(fragments, separator))
+		return k
+	}
+
+	k.clauses = append(k.clauses, kyleClause{keyword, []string{strings.Join(fragments, separator)}})
+
+	return k
+}
+
+func (k *Kyle) pattern(pattern interface{}) string {
+	return k.fragment(pattern, func(maxx *Maxine) string {
+		return maxx.Query
+	})
+}
+
+// variables returns the strings or the Maxines' variables. Only the variable
+// is used, so the Maxine's params are not merged into the query's
+func (k *Kyle) variables(items []interface{}) []string {
+	variables := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if maxx, ok := item.(*Maxine); ok && maxx != nil {
+			k.maxx.setErr(maxx.Err)
+			variables = append(variables, maxx.Variable)
+			continue
+		}
+
+		variables = append(variables, k.fragment(item, func(maxx *Maxine) string {
+			return maxx.Variable
+		}))
+	}
+
+	return variables
+}
+
+// fragment returns the string or the part of the Maxine that is used in the
+// clause. The Maxine's params and error are merged into the query's
+func (k *Kyle) fragment(value interface{}, part func(*Maxine) string) string {
+	switch fragment := value.(type) {
+	case string:
+		return fragment
+	case *Maxine:
+		if fragment == nil {
+			k.maxx.setErr(ErrNilEntity)
+			return ""
+		}
+
+		fragment = k.resolve(fragment)
+		k.maxx.setErr(fragment.Err)
+		k.merge(fragment.Params)
+
+		return part(fragment)
+	}
+
+	k.maxx.setErr(fmt.Errorf(`%w: got %T`, ErrInvalidFragment, value))
+
+	return ""
+}
+
+// resolve returns a copy of the Maxine whose params no longer collide with the
+// query's params when the strategy allows it
+func (k *Kyle) resolve(maxx *Maxine) *Maxine {
+	collisions := []string{}
+
+	for _, pair := range maxx.Params.Pairs() {
+		if existing, ok := k.maxx.Params[pair.Key]; ok && !reflect.DeepEqual(existing, pair.Value) {
+			collisions = append(collisions, pair.Key)
+		}
+	}
+
+	if len(collisions) == 0 || k.collisions == CollisionError {
+		return maxx
+	}
+
+	resolved := maxx.clone()
+
+	switch k.collisions {
+	case CollisionRename:
+		renames := map[string]string{}
+
+		for _, param := range collisions {
+			for i := 1; ; i++ {
+				name := fmt.Sprintf(`%s_%d`, param, i)
+				existing, used := k.maxx.Params[name]
+				_, own := resolved.Params[name]
+
+				// a previous rename of the same value is reused
+				if (!used || reflect.DeepEqual(existing, resolved.Params[param])) && !own {
+					renames[param] = name
+					break
+				}
+			}
+		}
+
+		resolved.setErr(resolved.renameParams(renames))
+
+	case CollisionPrefix:
+		resolved.setErr(resolved.PrefixParams(k.prefix(resolved)))
+	}
+
+	return resolved
+}
+
+// prefix returns the Maxine's variable as a param prefix. When that prefix is
+// already taken by a fragment with the same variable, it is numbered: friend_1_
+func (k *Kyle) prefix(maxx *Maxine) string {
+	prefix := maxx.Variable + "_"
+
+	for i := 1; ; i++ {
+		taken := false
+
+		for param, value := range maxx.Params {
+			if existing, ok := k.maxx.Params[prefix+param]; ok && !reflect.DeepEqual(existing, value) {
+				taken = true
+				break
+			}
+		}
+
+		if !taken {
+			return prefix
+		}
+
+		prefix = fmt.Sprintf(`%s_%d_`, maxx.Variable, i)
+	}
+}
+
+func (k *Kyle) merge(params M) {
+	k.maxx.setErr(k.maxx.MergeParamsE(true, params))
+}
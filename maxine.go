@@ -1,13 +1,95 @@
 package khadijah
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
+)
+
+// OptionsTagName is the tag that holds khadijah specific field options. Its
+// options are combined with the ones found in Maxine.TagName
+const OptionsTagName = "khadijah"
+
+// the options that can be added to a field's tag
+const (
+	OptionOmitEmpty  = "omitempty"
+	OptionOmitZero   = "omitzero"
+	OptionString     = "string"
+	OptionReadOnly   = "readonly"
+	OptionKey        = "key"
+	OptionFlatten    = "flatten"
+	OptionSerialize  = "serialize"
+	OptionLabel      = "label"
+	OptionAutoCreate = "autoCreate"
+	OptionAutoUpdate = "autoUpdate"
+	OptionVersion    = "version"
+)
+
+// TimestampExpression sets the autoCreate and autoUpdate properties when
+// there isn't a Clock
+const TimestampExpression = "datetime()"
+
+// Clock returns the time used for timestamps and soft deletes
+type Clock func() time.Time
+
+// Labeler is an entity that provides its own label, or edge type. It takes
+// precedence over a label declared with the label tag option
+type Labeler interface {
+	Label() string
+}
+
+// MultiLabeler is an entity that provides several labels
+type MultiLabeler interface {
+	Labels() []string
+}
+
+// Labeled is an entity along with the node labels that it is used with. They
+// take the place of the labels the entity declares. Build one with WithLabels
+type Labeled struct {
+	Entity interface{}
+	Labels []string
+}
+
+// StructMode defines how struct typed fields, like an Address, are turned into properties
+type StructMode int
+
+const (
+	// StructValue uses the struct as the param's value
+	StructValue StructMode = iota
+
+	// StructFlatten turns each of the struct's fields into a property that is
+	// prefixed with the field's name: address_city
+	StructFlatten
+
+	// StructSerialize uses the struct's JSON encoding as the param's value
+	StructSerialize
+
+	// StructSkip leaves the struct out
+	StructSkip
+)
+
+// NullPolicy defines how nil valued properties are handled when updating
+type NullPolicy int
+
+const (
+	// NullSet sets the property to null, which removes it from the entity
+	NullSet NullPolicy = iota
+
+	// NullSkip leaves the property out of the update, keeping its value
+	NullSkip
+
+	// NullRemove REMOVEs the property from the entity
+	NullRemove
 )
 
 // NewMaxine will create a new instance of Maxine with a given tagName and variable
-func NewMaxine(tagName, variable, paramPrefix string, matchClause M) *Maxine {
+func NewMaxine(tagName, variable, paramPrefix string, matchClause Clause) *Maxine {
 	maxx := &Maxine{
 		Params:             M{},
 		TagName:            tagName,
@@ -18,6 +100,10 @@ func NewMaxine(tagName, variable, paramPrefix string, matchClause M) *Maxine {
 
 	maxx.ParseMatchClause(matchClause)
 
+	if !IsSafeIdentifier(variable) {
+		maxx.setErr(fmt.Errorf(`%w: %q`, ErrInvalidVariable, variable))
+	}
+
 	return maxx
 }
 
@@ -28,6 +114,9 @@ type Maxine struct {
 	// holds the set information: "var.name = $name, var.age = $age, var.paramN = $paramN"
 	SetQuery string `json:"setQuery"`
 
+	// holds the remove information: "var.nickname, var.bio"
+	RemoveQuery string `json:"removeQuery"`
+
 	// holds the create params information: name: $name, age: $age, paramN: $paramN
 	CreateQuery string `json:"createQuery"`
 
@@ -46,9 +135,87 @@ type Maxine struct {
 	// Holds the name of the entity for use in situations where a label isn't provided
 	EntityName string `json:"entityName"`
 
+	// the labels declared by the entity, they are used in place of EntityName
+	Labels []string `json:"labels"`
+
 	MatchClause string `json:"matchClause"`
 
-	DefaultMatchClause M `json:"defaultMatchClause"`
+	DefaultMatchClause Clause `json:"defaultMatchClause"`
+
+	// holds every tagged field that was found in the entity, in struct order
+	Properties []Property `json:"properties"`
+
+	// defines how struct typed fields are handled
+	StructMode StructMode `json:"structMode"`
+
+	// defines how nil valued properties are handled when updating
+	NullPolicy NullPolicy `json:"nullPolicy"`
+
+	// when true, creates and updates SET the properties from a single map param
+	MapProperties bool `json:"mapProperties"`
+
+	// when true, the query text only depends on the entity type and the
+	// operation. It implies MapProperties
+	Normalize bool `json:"normalize"`
+
+	// when set, deletes set this property to $now in place of DELETE and
+	// reads skip the entities that have it
+	SoftDeleteProperty string `json:"softDeleteProperty"`
+
+	// the label that is added to soft deleted nodes
+	SoftDeleteLabel string `json:"softDeleteLabel"`
+
+	// when true, reads include the soft deleted entities
+	IncludeDeleted bool `json:"includeDeleted"`
+
+	// the time used for timestamps and soft deletes, datetime() is used for
+	// timestamps without one
+	Clock Clock `json:"-"`
+
+	// converts field values into params and record values into field values
+	Converters *Converters `json:"-"`
+
+	// true when the query only updates the entity if its version matches.
+	// When no rows are returned, the entity was changed by someone else and
+	// the update should be treated as an ErrVersionConflict
+	Versioned bool `json:"versioned"`
+
+	// when true, labels, property names, and params that need to be quoted
+	// are rejected with ErrUnsafeIdentifier instead
+	StrictIdentifiers bool `json:"strictIdentifiers"`
+
+	// holds the first problem that was found while building the query
+	Err error `json:"-"`
+}
+
+// Property describes a single tagged field that was found by Maxine.Parse
+type Property struct {
+	// the cypher property name
+	Name string `json:"name"`
+
+	// the name of the param that holds the value in Maxine.Params
+	Param string `json:"param"`
+
+	Value interface{} `json:"value"`
+
+	// excluded properties are left out of the queries, but their value is
+	// still added to the params so that they can be used in match clauses
+	Excluded bool `json:"excluded"`
+
+	// readonly properties are written when created, but never SET afterwards
+	ReadOnly bool `json:"readOnly"`
+
+	// key properties identify the entity and are never SET afterwards
+	Key bool `json:"key"`
+
+	// a cypher expression, like datetime(), that is used in place of the param
+	Expression string `json:"expression"`
+
+	// the version property is checked when updating and incremented when SET
+	Version bool `json:"version"`
+
+	// timestamps are set by the Clock, or an Expression, not by the field
+	Timestamp bool `json:"timestamp"`
 }
 
 // Parse does the work of converting a struct to query placeloders and
@@ -62,65 +229,848 @@ type Maxine struct {
 // 	   SetQuery: "u.email = $email, u.usrname = $username, u.password = $password",
 //     Parms: M{"email": entity.Email, "username": entity.Username, "password": entity.Password},
 // }
+// tags are read like encoding/json reads them, the name is followed by options:
+//     `json:"email,omitempty"` will leave a zero valued Email out of the query and params
+// the khadijah tag adds options (and can override the name) on top of that:
+//     `json:"created" khadijah:",readonly"`
 func (m *Maxine) Parse(entity interface{}, exclude ...string) *Maxine {
-	maxx := NewMaxine(m.TagName, m.Variable, m.ParamPefix, m.DefaultMatchClause)
+	maxx := m.derive(m.Variable, m.ParamPefix, m.DefaultMatchClause)
 	queryParams := []string{}
 	setParams := []string{}
-	entityType := reflect.TypeOf(entity)
+	removeParams := []string{}
+	labeled, isLabeled := entity.(Labeled)
 
-	// resolve the entity type and name
-	for entityType.Kind() == reflect.Ptr {
-		entityType = entityType.Elem()
+	if isLabeled {
+		entity = labeled.Entity
 	}
 
-	maxx.EntityName = entityType.Name()
 	entityValue := reflect.ValueOf(entity)
-	exclude = append(exclude, "-")
 
-	// for i := 0; i < entityType.NumField(); i++ {
-	for _, field := range reflect.VisibleFields(entityType) {
-		// field := entityType.Field(i)
-		tag, ok := field.Tag.Lookup(m.TagName)
-		if strings.TrimSpace(tag) == "" {
+	// resolve the entity value, type, and name
+	for entityValue.Kind() == reflect.Ptr && !entityValue.IsNil() {
+		entityValue = entityValue.Elem()
+	}
+
+	if !entityValue.IsValid() || entityValue.Kind() == reflect.Ptr {
+		maxx.setErr(ErrNilEntity)
+		return maxx
+	}
+
+	entityType := entityValue.Type()
+	maxx.EntityName = entityType.Name()
+
+	if entityType.Kind() != reflect.Struct {
+		maxx.setErr(fmt.Errorf(`%w: got %s`, ErrNotStruct, entityType.Kind()))
+		return maxx
+	}
+
+	maxx.Labels = entityLabels(entity, entityType)
+
+	if isLabeled {
+		maxx.Labels = labeled.Labels
+	}
+
+	for _, prop := range maxx.collectProperties(entityValue, "", exclude) {
+		// only add the param if it is not in the exclude list
+		if !prop.Excluded {
+			queryParams = append(queryParams, maxx.createEntry(prop))
+
+			if !prop.ReadOnly && !prop.Key {
+				switch {
+				case !maxx.isNull(prop) || maxx.NullPolicy == NullSet:
+					setParams = append(setParams, maxx.setEntry(prop))
+				case maxx.NullPolicy == NullRemove:
+					removeParams = append(removeParams, maxx.removeEntry(prop.Name))
+				}
+			}
+		}
+
+		if prop.Expression == "" {
+			maxx.Params[prop.Param] = prop.Value
+		}
+
+		maxx.Properties = append(maxx.Properties, prop)
+	}
+
+	if len(queryParams) > 0 {
+		maxx.CreateQuery = fmt.Sprintf(`{%s}`, strings.Join(queryParams, ", "))
+	}
+
+	maxx.SetQuery = strings.Join(setParams, ", ")
+	maxx.RemoveQuery = strings.Join(removeParams, ", ")
+
+	return maxx
+}
+
+// createEntry returns the "name: $param" entry for a property
+func (m *Maxine) createEntry(prop Property) string {
+	return fmt.Sprintf(`%s: %s`, m.quote(prop.Name), m.value(prop))
+}
+
+// patternQuery returns the "{name: $param}" map used in MATCH and MERGE
+// patterns. Timestamps are only written, never matched, so they are left out
+func (m *Maxine) patternQuery() string {
+	entries := []string{}
+
+	for _, prop := range m.Properties {
+		if prop.Excluded || prop.Timestamp {
+			continue
+		}
+
+		entries = append(entries, m.createEntry(prop))
+	}
+
+	if len(entries) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`{%s}`, strings.Join(entries, ", "))
+}
+
+// setEntry returns the "var.name = $param" entry for a property
+func (m *Maxine) setEntry(prop Property) string {
+	if prop.Version {
+		return fmt.Sprintf(`%s.%s = %s.%s + 1`, m.Variable, m.quote(prop.Name), m.Variable, m.quote(prop.Name))
+	}
+
+	return m.assignEntry(prop)
+}
+
+// assignEntry sets the property to its value, even when it is the version
+func (m *Maxine) assignEntry(prop Property) string {
+	return fmt.Sprintf(`%s.%s = %s`, m.Variable, m.quote(prop.Name), m.value(prop))
+}
+
+// removeEntry returns the "var.name" entry for a property
+func (m *Maxine) removeEntry(name string) string {
+	return fmt.Sprintf(`%s.%s`, m.Variable, m.quote(name))
+}
+
+// isNull is true when the property's value is nil and there isn't an
+// expression in its place
+func (m *Maxine) isNull(prop Property) bool {
+	return prop.Value == nil && prop.Expression == ""
+}
+
+// updateClauses returns the SET and REMOVE clauses built from the SetQuery
+// and RemoveQuery, either can be left out
+func (m *Maxine) updateClauses() string {
+	clauses := []string{}
+
+	if m.SetQuery != "" {
+		clauses = append(clauses, fmt.Sprintf(`SET %s`, m.SetQuery))
+	}
+
+	if m.RemoveQuery != "" {
+		clauses = append(clauses, fmt.Sprintf(`REMOVE %s`, m.RemoveQuery))
+	}
+
+	return strings.Join(clauses, " ")
+}
+
+// mapClause returns the "var = $props" SET entries that are used in place of
+// one entry per property, operator is = or +=. The map is added to the Params.
+// Expressions and the version increment can't be in the map, they follow it
+func (m *Maxine) mapClause(param, operator string, forUpdate bool) string {
+	entries := []string{fmt.Sprintf(`%s %s %s`, m.Variable, operator, m.placeholder(param))}
+	m.Params[param] = m.propertyMap(forUpdate)
+
+	return strings.Join(append(entries, m.mapEntries(forUpdate)...), ", ")
+}
+
+// mapEntries returns the SET entries of the properties that can't be in a map:
+// expressions, like the datetime() timestamps, and the version increment when updating
+func (m *Maxine) mapEntries(forUpdate bool) []string {
+	entries := []string{}
+
+	for _, prop := range m.Properties {
+		if prop.Excluded || (forUpdate && (prop.ReadOnly || prop.Key)) {
+			continue
+		}
+
+		switch {
+		case prop.Expression != "":
+			entries = append(entries, m.assignEntry(prop))
+		case forUpdate && prop.Version:
+			entries = append(entries, m.setEntry(prop))
+		}
+	}
+
+	return entries
+}
+
+// useMapClause replaces the SetQuery with the mapClause used for updates. Nulls
+// in the map are removed by +=, so there is nothing left to REMOVE
+func (m *Maxine) useMapClause() {
+	if !m.mapped() {
+		return
+	}
+
+	m.SetQuery = m.mapClause(m.GetTag(PropsParam), "+=", true)
+	m.RemoveQuery = ""
+}
+
+// trimParams drops the params that the query doesn't use when the properties
+// are SET from map params, they would be sent twice otherwise. The params used
+// by the match clause, keys, and version check are kept. The fragments that
+// aren't a part of the query are cleared, they would use the dropped params
+func (m *Maxine) trimParams() {
+	if !m.mapped() {
+		return
+	}
+
+	for _, fragment := range []*string{&m.CreateQuery, &m.MatchClause, &m.SetQuery, &m.RemoveQuery} {
+		if !strings.Contains(m.Query, *fragment) {
+			*fragment = ""
+		}
+	}
+
+	params := map[string]string{}
+	for param := range m.Params {
+		params[param] = param
+	}
+
+	placeholders := placeholderPattern(params)
+	if placeholders == nil {
+		return
+	}
+
+	used := map[string]bool{}
+	for _, placeholder := range placeholders.FindAllString(m.Query, -1) {
+		used[unquotePlaceholder(placeholder)] = true
+	}
+
+	for param := range m.Params {
+		if !used[param] {
+			delete(m.Params, param)
+		}
+	}
+}
+
+// mapped is true when the properties are SET from map params
+func (m *Maxine) mapped() bool {
+	return m.MapProperties || m.Normalize
+}
+
+// Fingerprint returns a hash of the query's shape: its text with the
+// whitespace collapsed. Params aren't a part of it, queries that only differ
+// by their values share a fingerprint, which makes it useful for metrics
+func (m *Maxine) Fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(m.Query), " ")))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// versionCondition returns the condition that only matches the entity when
+// its version is the same as the one in the source, $ or row.match. for
+// example, and marks the Maxine as Versioned. It is empty without a version property
+func (m *Maxine) versionCondition(source string) string {
+	for _, prop := range m.Properties {
+		if prop.Version && !prop.Excluded {
+			m.Versioned = true
+
+			return fmt.Sprintf(`%s.%s = %s%s`, m.Variable, m.quote(prop.Name), source, m.quote(prop.Param))
+		}
+	}
+
+	return ""
+}
+
+// matchParams returns the params that the match clause, and the version check
+// when withVersion is true, use. Batch rows hold them in place of every param
+func (m *Maxine) matchParams(matchClause Clause, withVersion bool) map[string]interface{} {
+	params := map[string]interface{}{}
+
+	if matchClause != nil {
+		for _, pair := range matchClause.Pairs() {
+			param := m.GetTag(pair.Value)
+
+			if value, ok := m.Params[param]; ok {
+				params[param] = value
+			}
+		}
+	}
+
+	for _, prop := range m.Properties {
+		if withVersion && prop.Version && !prop.Excluded {
+			params[prop.Param] = m.Params[prop.Param]
+		}
+	}
+
+	return params
+}
+
+// value returns the property's expression or its placeholder
+func (m *Maxine) value(prop Property) string {
+	if prop.Expression != "" {
+		return prop.Expression
+	}
+
+	return m.placeholder(prop.Param)
+}
+
+// now returns the time from the Clock or the current time
+func (m *Maxine) now() time.Time {
+	if m.Clock != nil {
+		return m.Clock()
+	}
+
+	return time.Now().UTC()
+}
+
+// placeholderPattern matches the $param and $`param` placeholders of the params
+func placeholderPattern(renames map[string]string) *regexp.Regexp {
+	params := make([]string, 0, len(renames))
+	for param := range renames {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+	alternatives := []string{}
+
+	for _, param := range params {
+		if IsSafeIdentifier(param) {
+			alternatives = append(alternatives, regexp.QuoteMeta(param)+`\b`)
+		}
+
+		alternatives = append(alternatives, regexp.QuoteMeta("`"+strings.ReplaceAll(param, "`", "``")+"`"))
+	}
+
+	if len(alternatives) == 0 {
+		return nil
+	}
+
+	return regexp.MustCompile(`\$(?:` + strings.Join(alternatives, "|") + `)`)
+}
+
+// unquotePlaceholder returns the param name found in a placeholder
+func unquotePlaceholder(placeholder string) string {
+	param := strings.TrimPrefix(placeholder, "$")
+
+	if strings.HasPrefix(param, "`") {
+		param = strings.ReplaceAll(param[1:len(param)-1], "``", "`")
+	}
+
+	return param
+}
+
+// placeholder returns the $param placeholder used in the query
+func (m *Maxine) placeholder(param string) string {
+	return "$" + m.quote(param)
+}
+
+// quote returns the identifier quoted with backticks when it isn't safe to
+// be used as is. In strict mode unsafe identifiers are rejected instead
+func (m *Maxine) quote(identifier string) string {
+	if IsSafeIdentifier(identifier) {
+		return identifier
+	}
+
+	if m.StrictIdentifiers {
+		m.setErr(fmt.Errorf(`%w: %q`, ErrUnsafeIdentifier, identifier))
+		return identifier
+	}
+
+	return QuoteIdentifier(identifier)
+}
+
+// mergeClauses builds the "key: $key" entries used in a MERGE pattern and the
+// " ON CREATE SET ... ON MATCH SET ..." clauses that follow it. When keyFields
+// is empty, the properties tagged as key are used. readonly properties are
+// only set on create
+func (m *Maxine) mergeClauses(keyFields []string) (keys string, onSet string) {
+	keys, keyFields = m.mergeKeys(keyFields)
+	onSet, removes := m.mergeActions(keyFields)
+
+	if removes != "" {
+		onSet = fmt.Sprintf(`%s REMOVE %s`, onSet, removes)
+	}
+
+	return keys, onSet
+}
+
+// mergeKeys returns the "{key: $key}" entries that a MERGE matches on, and the
+// key fields that were used. When keyFields is empty the properties tagged as key are used
+func (m *Maxine) mergeKeys(keyFields []string) (keys string, fields []string) {
+	if len(keyFields) == 0 {
+		for _, prop := range m.Properties {
+			if prop.Key {
+				keyFields = append(keyFields, prop.Name)
+			}
+		}
+	}
+
+	if len(keyFields) == 0 {
+		m.setErr(ErrNoKeyFields)
+	}
+
+	keyEntries := []string{}
+
+	for _, name := range keyFields {
+		prop, ok := m.property(name)
+		if !ok {
+			m.setErr(fmt.Errorf(`%w: $%s`, ErrMissingMatchParam, m.GetTag(name)))
+			continue
+		}
+
+		keyEntries = append(keyEntries, m.createEntry(prop))
+	}
+
+	return strings.Join(keyEntries, ", "), keyFields
+}
+
+// mergeActions returns the ON CREATE SET and ON MATCH SET clauses for the
+// properties that aren't keys, and the entries to REMOVE when NullRemove is used
+func (m *Maxine) mergeActions(keyFields []string) (onSet string, removes string) {
+	if m.mapped() {
+		onCreate := m.mapClause(m.GetTag(PropsParam), "+=", false)
+		onMatch := m.mapClause(m.GetTag(OnMatchPropsParam), "+=", true)
+
+		return fmt.Sprintf(` ON CREATE SET %s ON MATCH SET %s`, onCreate, onMatch), ""
+	}
+
+	onCreate := []string{}
+	onMatch := []string{}
+	removeEntries := []string{}
+
+	for _, prop := range m.Properties {
+		if prop.Excluded || prop.Key || Contains(keyFields, prop.Name) {
+			continue
+		}
+
+		// nulls are left out of both, the entity doesn't have them on create
+		if m.isNull(prop) && m.NullPolicy != NullSet {
+			if m.NullPolicy == NullRemove && !prop.ReadOnly {
+				removeEntries = append(removeEntries, m.removeEntry(prop.Name))
+			}
+
+			continue
+		}
+
+		onCreate = append(onCreate, m.assignEntry(prop))
+
+		if !prop.ReadOnly {
+			onMatch = append(onMatch, m.setEntry(prop))
+		}
+	}
+
+	if len(onCreate) > 0 {
+		onSet = fmt.Sprintf(` ON CREATE SET %s`, strings.Join(onCreate, ", "))
+	}
+
+	if len(onMatch) > 0 {
+		onSet = fmt.Sprintf(`%s ON MATCH SET %s`, onSet, strings.Join(onMatch, ", "))
+	}
+
+	return onSet, strings.Join(removeEntries, ", ")
+}
+
+// pathKeys returns the names of the properties tagged as key that have a
+// value, they identify the entity in a path pattern. When normalizing, the
+// nil keys are kept so that the pattern doesn't depend on the values
+func (m *Maxine) pathKeys() []string {
+	keys := []string{}
+
+	for _, prop := range m.Properties {
+		if prop.Key && !prop.Excluded && (m.Normalize || !m.isNull(prop)) {
+			keys = append(keys, prop.Name)
+		}
+	}
+
+	return keys
+}
+
+// property returns the property with the given name
+func (m *Maxine) property(name string) (Property, bool) {
+	for _, prop := range m.Properties {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+
+	return Property{}, false
+}
+
+// derive creates a new, empty, Maxine that shares this instance's settings but
+// uses a different variable, param prefix, and match clause
+func (m *Maxine) derive(variable, paramPrefix string, matchClause Clause) *Maxine {
+	maxx := NewMaxine(m.TagName, variable, paramPrefix, matchClause)
+	maxx.StrictIdentifiers = m.StrictIdentifiers
+	maxx.StructMode = m.StructMode
+	maxx.NullPolicy = m.NullPolicy
+	maxx.MapProperties = m.MapProperties
+	maxx.Normalize = m.Normalize
+	maxx.Converters = m.Converters
+	maxx.SoftDeleteProperty = m.SoftDeleteProperty
+	maxx.SoftDeleteLabel = m.SoftDeleteLabel
+	maxx.IncludeDeleted = m.IncludeDeleted
+	maxx.Clock = m.Clock
+
+	return maxx
+}
+
+// notDeleted returns the condition that skips the soft deleted entities bound
+// to the variable, it is empty when they are included
+func (m *Maxine) notDeleted(variable string) string {
+	if m.SoftDeleteProperty == "" || m.IncludeDeleted {
+		return ""
+	}
+
+	return fmt.Sprintf(`%s.%s IS NULL`, variable, m.quote(m.SoftDeleteProperty))
+}
+
+// excludeDeleted adds the notDeleted condition to the MatchClause
+func (m *Maxine) excludeDeleted() {
+	m.MatchClause = joinConditions(m.MatchClause, m.notDeleted(m.Variable))
+}
+
+// softDelete returns the SET clause used in place of DELETE and adds the $now
+// param. The label is only added to nodes
+func (m *Maxine) softDelete(node bool) string {
+	now := m.GetTag(SoftDeleteParam)
+	m.Params[now] = m.now()
+	set := fmt.Sprintf(`SET %s.%s = %s`, m.Variable, m.quote(m.SoftDeleteProperty), m.placeholder(now))
+
+	if node && m.SoftDeleteLabel != "" {
+		set = fmt.Sprintf(`%s, %s:%s`, set, m.Variable, m.labels(&m.SoftDeleteLabel))
+	}
+
+	return set
+}
+
+// clone returns a copy of the Maxine that can be changed without changing it
+func (m *Maxine) clone() *Maxine {
+	maxx := *m
+	maxx.Params = M{}
+	maxx.Properties = append([]Property{}, m.Properties...)
+
+	for param, value := range m.Params {
+		maxx.Params[param] = value
+	}
+
+	return &maxx
+}
+
+// parseBatch parses every entity in the entities slice. The returned Maxine
+// holds the first entity's name and properties along with any error found
+func (m *Maxine) parseBatch(entities interface{}, exclude ...string) (*Maxine, []*Maxine) {
+	maxx := m.derive(m.Variable, m.ParamPefix, m.DefaultMatchClause)
+	entitiesValue := reflect.ValueOf(entities)
+
+	for entitiesValue.Kind() == reflect.Ptr && !entitiesValue.IsNil() {
+		entitiesValue = entitiesValue.Elem()
+	}
+
+	if entitiesValue.Kind() != reflect.Slice && entitiesValue.Kind() != reflect.Array {
+		maxx.setErr(fmt.Errorf(`%w: got %s`, ErrNotSlice, entitiesValue.Kind()))
+		return maxx, nil
+	}
+
+	if entitiesValue.Len() == 0 {
+		maxx.setErr(ErrEmptyBatch)
+		return maxx, nil
+	}
+
+	parsed := make([]*Maxine, 0, entitiesValue.Len())
+
+	for i := 0; i < entitiesValue.Len(); i++ {
+		item := m.Parse(entitiesValue.Index(i).Interface(), exclude...)
+		maxx.setErr(item.Err)
+		parsed = append(parsed, item)
+	}
+
+	maxx.EntityName = parsed[0].EntityName
+	maxx.Labels = parsed[0].Labels
+	maxx.Properties = parsed[0].Properties
+
+	return maxx, parsed
+}
+
+// propertyMap returns the property => value map of the properties that are
+// not excluded. When forUpdate is true, readonly and key properties are left out.
+// Expressions can't be in a map, mapEntries sets them
+func (m *Maxine) propertyMap(forUpdate bool) map[string]interface{} {
+	props := map[string]interface{}{}
+
+	for _, prop := range m.Properties {
+		if prop.Excluded || prop.Expression != "" || (forUpdate && (prop.ReadOnly || prop.Key || prop.Version)) {
+			continue
+		}
+
+		// += REMOVEs the properties that are null, which NullRemove wants
+		if forUpdate && m.NullPolicy == NullSkip && m.isNull(prop) {
 			continue
 		}
 
-		tagFixed := m.GetTag(tag)
-		fieldValue := entityValue.FieldByName(field.Name)
+		props[prop.Name] = prop.Value
+	}
+
+	return props
+}
+
+// collectProperties returns the properties found in the struct value. Every
+// property name is prefixed with prefix, which is how nested structs are flattened
+func (m *Maxine) collectProperties(value reflect.Value, prefix string, exclude []string) []Property {
+	props := []Property{}
+
+	for _, field := range m.taggedFields(value.Type()) {
+		fieldValue, err := value.FieldByIndexErr(field.Index)
+
+		// the field is in a nil embedded struct pointer, it has no value
+		if err != nil {
+			continue
+		}
 
 		// if we cant abstract the value, do not include the field
 		if !fieldValue.CanInterface() {
 			continue
 		}
 
-		// only add the param if it is not in the exclude list
-		if ok && !Contains(exclude, tag) {
-			queryParams = append(queryParams, fmt.Sprintf(`%s: $%s`, tag, tagFixed))
-			setParams = append(setParams, fmt.Sprintf(`%s.%s = $%s`, maxx.Variable, tag, tagFixed))
+		autoCreate := field.opts.Contains(OptionAutoCreate)
+		autoUpdate := field.opts.Contains(OptionAutoUpdate)
+
+		// timestamps, versions, and keys are never omitted, a missing version
+		// or key would quietly drop the check that uses it
+		required := autoCreate || autoUpdate || field.opts.Contains(OptionVersion) || field.opts.Contains(OptionKey)
+
+		if field.opts.Contains(OptionOmitEmpty) && isEmptyValue(fieldValue) && !required {
+			continue
+		}
+
+		if field.opts.Contains(OptionOmitZero) && fieldValue.IsZero() && !required {
+			continue
 		}
 
-		value := fieldValue.Interface()
-		maxx.Params[tagFixed] = value
+		name := prefix + field.name
+		excluded := Contains(exclude, name)
+
+		prop := Property{
+			Name:     name,
+			Param:    m.GetTag(name),
+			Excluded: excluded,
+			ReadOnly: field.opts.Contains(OptionReadOnly) || autoCreate,
+			Key:      field.opts.Contains(OptionKey),
+			Version:  field.opts.Contains(OptionVersion),
+		}
+
+		// timestamps are set by the clock, or by the database without one
+		if autoCreate || autoUpdate {
+			prop.Value = m.now()
+			prop.Timestamp = true
+
+			if m.Clock == nil {
+				prop.Expression = TimestampExpression
+			}
+
+			props = append(props, prop)
+			continue
+		}
+
+		if field.opts.Contains(OptionString) {
+			prop.Value = stringValue(fieldValue)
+			props = append(props, prop)
+			continue
+		}
+
+		// converters are applied before the default struct mode, but a field's
+		// own flatten or serialize option wins over them
+		structField := isStructType(field.Type)
+		explicit := structField && (field.opts.Contains(OptionFlatten) || field.opts.Contains(OptionSerialize))
+
+		if !explicit {
+			converted, ok, err := m.Converters.convert(fieldValue)
+			if err != nil {
+				m.setErr(fmt.Errorf(`%s: %w`, name, err))
+				continue
+			}
+
+			if ok {
+				prop.Value = converted
+				props = append(props, prop)
+				continue
+			}
+		}
+
+		if structField {
+			switch m.structMode(field.opts) {
+			case StructFlatten:
+				nested := reflect.Indirect(fieldValue)
+				if !nested.IsValid() {
+					continue
+				}
+
+				for _, prop := range m.collectProperties(nested, name+"_", exclude) {
+					prop.Excluded = prop.Excluded || excluded
+					props = append(props, prop)
+				}
+
+				continue
+
+			case StructSerialize:
+				// a nil struct is null like any other nil property, not "null"
+				if paramValue(fieldValue) == nil {
+					break
+				}
+
+				encoded, err := json.Marshal(fieldValue.Interface())
+				if err != nil {
+					m.setErr(fmt.Errorf(`%s: %w`, name, err))
+					continue
+				}
+
+				fieldValue = reflect.ValueOf(string(encoded))
+
+			case StructSkip:
+				continue
+			}
+		}
+
+		prop.Value = paramValue(fieldValue)
+		props = append(props, prop)
 	}
 
-	if len(queryParams) > 0 {
-		maxx.CreateQuery = fmt.Sprintf(`{%s}`, strings.Join(queryParams, ", "))
+	return props
+}
+
+// paramValue returns the value that the pointers point to, nil pointers are nil
+func paramValue(value reflect.Value) interface{} {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
 	}
 
-	maxx.SetQuery = strings.Join(setParams, ", ")
+	return value.Interface()
+}
 
-	return maxx
+// structMode returns how a struct typed field is handled, its tag options
+// take precedence over Maxine.StructMode
+func (m *Maxine) structMode(opts tagOptions) StructMode {
+	switch {
+	case opts.Contains(OptionFlatten):
+		return StructFlatten
+	case opts.Contains(OptionSerialize):
+		return StructSerialize
+	}
+
+	return m.StructMode
+}
+
+// taggedField is a struct field with a usable tag
+type taggedField struct {
+	reflect.StructField
+	name string
+	opts tagOptions
+}
+
+// taggedFields returns the struct type's fields that have a usable tag, in
+// the order that they were defined. Like encoding/json, the fields of an
+// embedded struct are promoted unless the embedded struct has a tag name
+func (m *Maxine) taggedFields(structType reflect.Type) []taggedField {
+	fields := []taggedField{}
+	hidden := [][]int{}
+
+	for _, field := range reflect.VisibleFields(structType) {
+		if hasIndexPrefix(field.Index, hidden) {
+			continue
+		}
+
+		if field.Anonymous {
+			tagName, _ := parseTag(field.Tag.Get(m.TagName))
+			khadName, _ := parseTag(field.Tag.Get(OptionsTagName))
+
+			// the embedded struct's fields are promoted, it isn't a field itself
+			if tagName == "" && khadName == "" {
+				continue
+			}
+
+			hidden = append(hidden, field.Index)
+		}
+
+		name, opts, ok := m.fieldTag(field)
+		if !ok || opts.Contains(OptionLabel) {
+			continue
+		}
+
+		fields = append(fields, taggedField{field, name, opts})
+	}
+
+	return fields
 }
 
-func (m *Maxine) ParseMatchClause(matchClause M) {
+// hasIndexPrefix reports if the field index is nested in any of the prefixes
+func hasIndexPrefix(index []int, prefixes [][]int) bool {
+	for _, prefix := range prefixes {
+		if len(index) > len(prefix) && reflect.DeepEqual(index[:len(prefix)], prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isStructType reports if the type is a struct, or a pointer to one, that
+// should be handled by the StructMode. time.Time is a value, not a struct
+func isStructType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// fieldTag resolves the cypher property name and the combined options for
+// a struct field. ok is false when the field should not be used
+func (m *Maxine) fieldTag(field reflect.StructField) (name string, opts tagOptions, ok bool) {
+	tag := field.Tag.Get(m.TagName)
+	khadTag := field.Tag.Get(OptionsTagName)
+
+	if strings.TrimSpace(tag) == "" && strings.TrimSpace(khadTag) == "" {
+		return "", "", false
+	}
+
+	name, opts = parseTag(tag)
+	khadName, khadOpts := parseTag(khadTag)
+
+	if (name == "-" && opts == "") || (khadName == "-" && khadOpts == "") {
+		return "", "", false
+	}
+
+	if khadName != "" {
+		name = khadName
+	}
+
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, opts + "," + khadOpts, true
+}
+
+// ParseMatchClause builds the MatchClause string from the clause's pairs,
+// in the order that the clause returns them
+func (m *Maxine) ParseMatchClause(matchClause Clause) {
+	m.parseMatchClause(matchClause, "$")
+}
+
+// parseMatchClause works like ParseMatchClause, but the params are read from
+// source. "$" reads query params, "row." would read from an UNWIND variable
+func (m *Maxine) parseMatchClause(matchClause Clause, source string) {
+	if matchClause == nil {
+		return
+	}
+
 	clauses := []string{}
 
-	for k, v := range matchClause {
-		tagValue := m.GetTag(v)
+	for _, pair := range matchClause.Pairs() {
+		k := pair.Key
+		tagValue := m.GetTag(pair.Value)
 
 		if strings.Contains(k, "id(") {
 			k = strings.Replace(k, "+v+", m.Variable, -1)
-			clauses = append(clauses, fmt.Sprintf(`%s = $%s`, k, tagValue))
+			clauses = append(clauses, fmt.Sprintf(`%s = %s%s`, k, source, m.quote(tagValue)))
 			continue
 		}
 
@@ -128,7 +1078,12 @@ func (m *Maxine) ParseMatchClause(matchClause M) {
 			k = strings.Replace(k, "+v+", m.Variable, -1)
 		}
 
-		clauses = append(clauses, fmt.Sprintf(`%s = $%s`, k, tagValue))
+		clauses = append(clauses, fmt.Sprintf(`%s = %s%s`, k, source, m.quote(tagValue)))
+	}
+
+	// the order of an OM changes the query, normalized ones don't
+	if m.Normalize {
+		sort.Strings(clauses)
 	}
 
 	if len(clauses) > 0 {
@@ -136,6 +1091,144 @@ func (m *Maxine) ParseMatchClause(matchClause M) {
 	}
 }
 
+// checkMatchClause ensures that the clause isn't empty and that every param
+// that it uses can be found in Maxine.Params
+func (m *Maxine) checkMatchClause(matchClause Clause) {
+	if matchClause == nil || len(matchClause.Pairs()) == 0 {
+		m.setErr(ErrEmptyMatchClause)
+		return
+	}
+
+	for _, pair := range matchClause.Pairs() {
+		param := m.GetTag(pair.Value)
+
+		if _, ok := m.Params[param]; !ok {
+			m.setErr(fmt.Errorf(`%w: $%s`, ErrMissingMatchParam, param))
+		}
+	}
+}
+
+// checkProperties ensures that the entity had at least one tagged property
+func (m *Maxine) checkProperties() {
+	if len(m.Properties) == 0 {
+		m.setErr(ErrNoProperties)
+	}
+}
+
+// label returns the quoted edge type. It is the provided label, the label
+// declared by the entity, or the entity's name, in that order
+func (m *Maxine) label(label *string) string {
+	if label == nil && len(m.Labels) > 0 {
+		return m.quote(strings.Join(m.Labels, ":"))
+	}
+
+	name := m.labelName(label)
+	if name == "" {
+		return name
+	}
+
+	return m.quote(name)
+}
+
+// labels works like label, but quotes each of the labels declared by the
+// entity as a node label: Person:Employee. A provided label is always a single
+// label, "User:Admin" is quoted as `User:Admin`
+func (m *Maxine) labels(label *string) string {
+	if label == nil && len(m.Labels) > 0 {
+		return m.joinLabels(m.Labels)
+	}
+
+	return m.label(label)
+}
+
+// joinLabels quotes each label and joins them with colons
+func (m *Maxine) joinLabels(labels []string) string {
+	quoted := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		if label == "" {
+			m.setErr(ErrEmptyLabel)
+			continue
+		}
+
+		quoted = append(quoted, m.quote(label))
+	}
+
+	return strings.Join(quoted, ":")
+}
+
+// joinTypes quotes each edge type and joins them with pipes
+func (m *Maxine) joinTypes(types []string) string {
+	quoted := make([]string, 0, len(types))
+
+	for _, edgeType := range types {
+		if edgeType == "" {
+			m.setErr(ErrEmptyLabel)
+			continue
+		}
+
+		quoted = append(quoted, m.quote(edgeType))
+	}
+
+	return strings.Join(quoted, "|")
+}
+
+func (m *Maxine) labelName(label *string) string {
+	if label == nil {
+		label = &m.EntityName
+	}
+
+	if *label == "" {
+		m.setErr(ErrEmptyLabel)
+	}
+
+	return *label
+}
+
+// entityLabels returns the labels provided by a Labeler or MultiLabeler entity
+// or the ones declared by its struct type. A Labeler provides a single label
+func entityLabels(entity interface{}, entityType reflect.Type) []string {
+	switch labeler := entity.(type) {
+	case Labeler:
+		if label := labeler.Label(); label != "" {
+			return []string{label}
+		}
+
+		return nil
+	case MultiLabeler:
+		return labeler.Labels()
+	}
+
+	if label := structLabel(entityType); label != "" {
+		return strings.Split(label, ":")
+	}
+
+	return nil
+}
+
+// structLabel returns the label declared by the struct type with a field
+// that uses the label tag option, the field is usually a blank one:
+//
+//	_ struct{} `khadijah:"Person:Employee,label"`
+func structLabel(structType reflect.Type) string {
+	for _, field := range reflect.VisibleFields(structType) {
+		name, opts := parseTag(field.Tag.Get(OptionsTagName))
+
+		if opts.Contains(OptionLabel) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// setErr keeps the first error that was found
+func (m *Maxine) setErr(err error) {
+	if m.Err == nil {
+		m.Err = err
+	}
+}
+
 func (m *Maxine) GetTag(tag interface{}) string {
 	return fmt.Sprintf(`%s%s`, m.ParamPefix, tag)
 }
@@ -145,15 +1238,95 @@ func (m *Maxine) MergeParams(params ...M) {
 }
 
 func (m *Maxine) MergeParamsSafe(ensureUnique bool, params ...M) {
+	m.MergeParamsE(ensureUnique, params...)
+}
+
+// MergeParamsE works like MergeParamsSafe, but returns the first param that
+// already held a different value as a ParamCollisionError. When ensureUnique
+// is true the existing value is kept, otherwise it is overwritten
+func (m *Maxine) MergeParamsE(ensureUnique bool, params ...M) error {
+	var err error
+
 	for _, param := range params {
-		for k, v := range param {
-			if ensureUnique {
-				if _, ok := m.Params[k]; !ok {
-					m.Params[k] = v
-				}
-			} else {
-				m.Params[k] = v
+		for _, pair := range param.Pairs() {
+			existing, ok := m.Params[pair.Key]
+			if ok && err == nil && !reflect.DeepEqual(existing, pair.Value) {
+				err = &ParamCollisionError{pair.Key, existing, pair.Value}
 			}
+
+			if !ok || !ensureUnique {
+				m.Params[pair.Key] = pair.Value
+			}
+		}
+	}
+
+	return err
+}
+
+// RenameParam renames the param and rewrites its placeholders in the query
+// fragments: $id becomes $user_id. Renaming to a param that already exists
+// results in a ParamCollisionError
+func (m *Maxine) RenameParam(param, name string) error {
+	return m.renameParams(map[string]string{param: name})
+}
+
+// PrefixParams prefixes every param with prefix and rewrites the placeholders
+// in the query fragments
+func (m *Maxine) PrefixParams(prefix string) error {
+	renames := map[string]string{}
+	for param := range m.Params {
+		renames[param] = prefix + param
+	}
+
+	return m.renameParams(renames)
+}
+
+// renameParams renames every param at once so that a renamed param is never
+// renamed twice
+func (m *Maxine) renameParams(renames map[string]string) error {
+	for _, pair := range m.Params.Pairs() {
+		if _, renamed := renames[pair.Key]; renamed {
+			continue
+		}
+
+		for param, name := range renames {
+			if name == pair.Key {
+				return &ParamCollisionError{name, pair.Value, m.Params[param]}
+			}
+		}
+	}
+
+	params := M{}
+	for param, value := range m.Params {
+		if name, ok := renames[param]; ok {
+			param = name
 		}
+
+		params[param] = value
 	}
+
+	for i, prop := range m.Properties {
+		if name, ok := renames[prop.Param]; ok {
+			m.Properties[i].Param = name
+		}
+	}
+
+	placeholders := placeholderPattern(renames)
+	rewrite := func(fragment string) string {
+		if placeholders == nil {
+			return fragment
+		}
+
+		return placeholders.ReplaceAllStringFunc(fragment, func(placeholder string) string {
+			return m.placeholder(renames[unquotePlaceholder(placeholder)])
+		})
+	}
+
+	m.Params = params
+	m.Query = rewrite(m.Query)
+	m.SetQuery = rewrite(m.SetQuery)
+	m.CreateQuery = rewrite(m.CreateQuery)
+	m.MatchClause = rewrite(m.MatchClause)
+
+	return nil
 }
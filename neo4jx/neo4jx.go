@@ -0,0 +1,243 @@
+// Package neo4jx runs the queries built by khadijah and hydrates their
+// results. It works with anything that satisfies Runner, the official neo4j
+// driver's sessions and transactions can be adapted with Session and Transaction
+package neo4jx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/emehrkay/khadijah"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// ensure that the driver types work with the adapters and Hydrate
+var (
+	_ TransactionRunner   = neo4j.ManagedTransaction(nil)
+	_ TransactionRunner   = neo4j.ExplicitTransaction(nil)
+	_ khadijah.Propertied = dbtype.Node{}
+	_ khadijah.Propertied = dbtype.Relationship{}
+)
+
+// ErrNoRecords is returned by Fetch when a single struct is expected but the
+// query didn't return any records
+var ErrNoRecords = errors.New("neo4jx: the query did not return any records")
+
+// Result is the part of a query result that neo4jx reads
+type Result interface {
+	// Next advances to the next record, it returns false when there are no more
+	Next(ctx context.Context) bool
+
+	// Record returns the current record as column => value
+	Record() map[string]interface{}
+
+	// Err returns the error that caused Next to return false
+	Err() error
+}
+
+// Runner runs a cypher query with its params
+type Runner interface {
+	Run(ctx context.Context, query string, params map[string]interface{}) (Result, error)
+}
+
+// RunnerFunc allows a plain function to be used as a Runner
+type RunnerFunc func(ctx context.Context, query string, params map[string]interface{}) (Result, error)
+
+// Run calls the function
+func (fn RunnerFunc) Run(ctx context.Context, query string, params map[string]interface{}) (Result, error) {
+	return fn(ctx, query, params)
+}
+
+// TransactionRunner is satisfied by the driver's neo4j.ManagedTransaction and
+// neo4j.ExplicitTransaction
+type TransactionRunner interface {
+	Run(ctx context.Context, cypher string, params map[string]interface{}) (neo4j.ResultWithContext, error)
+}
+
+// Session adapts a neo4j driver session to a Runner
+func Session(session neo4j.SessionWithContext, configurers ...func(*neo4j.TransactionConfig)) Runner {
+	return RunnerFunc(func(ctx context.Context, query string, params map[string]interface{}) (Result, error) {
+		result, err := session.Run(ctx, query, params, configurers...)
+		if err != nil {
+			return nil, err
+		}
+
+		return &driverResult{result}, nil
+	})
+}
+
+// Transaction adapts a neo4j driver transaction to a Runner
+func Transaction(tx TransactionRunner) Runner {
+	return RunnerFunc(func(ctx context.Context, query string, params map[string]interface{}) (Result, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return &driverResult{result}, nil
+	})
+}
+
+// driverResult adapts the driver's result to Result
+type driverResult struct {
+	result neo4j.ResultWithContext
+}
+
+func (r *driverResult) Next(ctx context.Context) bool {
+	return r.result.Next(ctx)
+}
+
+func (r *driverResult) Record() map[string]interface{} {
+	record := r.result.Record()
+	if record == nil {
+		return nil
+	}
+
+	return record.AsMap()
+}
+
+func (r *driverResult) Err() error {
+	return r.result.Err()
+}
+
+// Exec runs the query and discards any records that it returns. The error
+// found while building the query is returned without running it. A versioned
+// update that returns fewer records than it updates results in khadijah.ErrVersionConflict
+func Exec(ctx context.Context, runner Runner, maxx *khadijah.Maxine) error {
+	result, err := run(ctx, runner, maxx)
+	if err != nil {
+		return err
+	}
+
+	records := 0
+	for result.Next(ctx) {
+		records++
+	}
+
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	return checkVersion(maxx, records)
+}
+
+// Fetch runs the query and hydrates the records into dest. dest can be a
+// pointer to a struct, which will be filled with the first record, or a
+// pointer to a slice of structs or struct pointers. The values are read from
+// the column named after the Maxine's variable, or the only column that was returned
+func Fetch(ctx context.Context, runner Runner, maxx *khadijah.Maxine, dest interface{}) error {
+	return FetchColumn(ctx, runner, maxx, maxx.Variable, dest)
+}
+
+// FetchColumn works like Fetch, but reads the values from the named column
+func FetchColumn(ctx context.Context, runner Runner, maxx *khadijah.Maxine, column string, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		return fmt.Errorf(`%w: got %T`, khadijah.ErrNotPointer, dest)
+	}
+
+	result, err := run(ctx, runner, maxx)
+	if err != nil {
+		return err
+	}
+
+	target := destValue.Elem()
+	isSlice := target.Kind() == reflect.Slice
+	found := false
+	records := 0
+	var items reflect.Value
+
+	if isSlice {
+		items = reflect.MakeSlice(target.Type(), 0, 0)
+	}
+
+	for result.Next(ctx) {
+		records++
+		value, err := columnValue(result.Record(), column)
+		if err != nil {
+			return err
+		}
+
+		if !isSlice {
+			if !found {
+				if err := maxx.Hydrate(value, dest); err != nil {
+					return err
+				}
+			}
+
+			found = true
+			continue
+		}
+
+		item := reflect.New(target.Type().Elem())
+		if err := maxx.Hydrate(value, item.Interface()); err != nil {
+			return err
+		}
+
+		items = reflect.Append(items, item.Elem())
+	}
+
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	if err := checkVersion(maxx, records); err != nil {
+		return err
+	}
+
+	if isSlice {
+		target.Set(items)
+		return nil
+	}
+
+	if !found {
+		return ErrNoRecords
+	}
+
+	return nil
+}
+
+// checkVersion returns khadijah.ErrVersionConflict when a versioned update
+// returned fewer records than the number of rows that it updates
+func checkVersion(maxx *khadijah.Maxine, records int) error {
+	if !maxx.Versioned {
+		return nil
+	}
+
+	expected := 1
+	if rows, ok := maxx.Params[maxx.GetTag(khadijah.RowsParam)].([]interface{}); ok {
+		expected = len(rows)
+	}
+
+	if records < expected {
+		return khadijah.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func run(ctx context.Context, runner Runner, maxx *khadijah.Maxine) (Result, error) {
+	if maxx.Err != nil {
+		return nil, maxx.Err
+	}
+
+	return runner.Run(ctx, maxx.Query, map[string]interface{}(maxx.Params))
+}
+
+// columnValue returns the value in the column or the only value in the record
+func columnValue(record map[string]interface{}, column string) (interface{}, error) {
+	if value, ok := record[column]; ok {
+		return value, nil
+	}
+
+	if len(record) == 1 {
+		for _, value := range record {
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf(`neo4jx: column %q was not found in the record`, column)
+}
@@ -0,0 +1,221 @@
+package neo4jx_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	k "github.com/emehrkay/khadijah"
+	"github.com/emehrkay/khadijah/neo4jx"
+)
+
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type node struct {
+	props map[string]interface{}
+}
+
+func (n node) GetProperties() map[string]interface{} {
+	return n.props
+}
+
+type fakeResult struct {
+	records []map[string]interface{}
+	index   int
+	err     error
+}
+
+func (r *fakeResult) Next(ctx context.Context) bool {
+	if r.index >= len(r.records) {
+		return false
+	}
+
+	r.index++
+
+	return true
+}
+
+func (r *fakeResult) Record() map[string]interface{} {
+	return r.records[r.index-1]
+}
+
+func (r *fakeResult) Err() error {
+	return r.err
+}
+
+type fakeRunner struct {
+	query   string
+	params  map[string]interface{}
+	records []map[string]interface{}
+	calls   int
+}
+
+func (f *fakeRunner) Run(ctx context.Context, query string, params map[string]interface{}) (neo4jx.Result, error) {
+	f.calls++
+	f.query = query
+	f.params = params
+
+	return &fakeResult{records: f.records}, nil
+}
+
+var (
+	label = "User"
+	mark  = User{ID: "1", Name: "mark", Email: "spam@aol.com"}
+	other = User{ID: "2", Name: "other", Email: "other@aol.com"}
+)
+
+func userRecord(column string, user User) map[string]interface{} {
+	return map[string]interface{}{
+		column: node{map[string]interface{}{"id": user.ID, "name": user.Name, "email": user.Email}},
+	}
+}
+
+func TestExec(t *testing.T) {
+	instance := k.New()
+	maxx := instance.CreateNode(mark, &label, false)
+	runner := &fakeRunner{}
+
+	if err := neo4jx.Exec(context.Background(), runner, maxx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if runner.query != maxx.Query {
+		t.Errorf("\nexpected: \n\t%s \nbut got: \n\t%v\n", maxx.Query, runner.query)
+	}
+
+	if !reflect.DeepEqual(runner.params, map[string]interface{}(maxx.Params)) {
+		t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", maxx.Params, runner.params)
+	}
+
+	t.Run("query errors are returned without running", func(t *testing.T) {
+		runner := &fakeRunner{}
+		maxx := instance.CreateNode(nil, &label, false)
+
+		if err := neo4jx.Exec(context.Background(), runner, maxx); !errors.Is(err, k.ErrNilEntity) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrNilEntity, err)
+		}
+
+		if runner.calls != 0 {
+			t.Errorf("expected the runner not to be called, but it was called %d times", runner.calls)
+		}
+	})
+}
+
+func TestFetch(t *testing.T) {
+	instance := k.New()
+	maxx := instance.MatchNode(mark, &label, true)
+
+	t.Run("single struct", func(t *testing.T) {
+		runner := &fakeRunner{records: []map[string]interface{}{userRecord("flava", mark)}}
+		user := User{}
+
+		if err := neo4jx.Fetch(context.Background(), runner, maxx, &user); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user != mark {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", mark, user)
+		}
+	})
+
+	t.Run("slice of structs", func(t *testing.T) {
+		runner := &fakeRunner{records: []map[string]interface{}{userRecord("flava", mark), userRecord("flava", other)}}
+		users := []User{}
+
+		if err := neo4jx.Fetch(context.Background(), runner, maxx, &users); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(users, []User{mark, other}) {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", []User{mark, other}, users)
+		}
+	})
+
+	t.Run("slice of struct pointers from the only column", func(t *testing.T) {
+		runner := &fakeRunner{records: []map[string]interface{}{userRecord("u", mark)}}
+		users := []*User{}
+
+		if err := neo4jx.Fetch(context.Background(), runner, maxx, &users); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(users) != 1 || *users[0] != mark {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", mark, users)
+		}
+	})
+
+	t.Run("named column", func(t *testing.T) {
+		record := userRecord("start", mark)
+		record["end"] = userRecord("end", other)["end"]
+		runner := &fakeRunner{records: []map[string]interface{}{record}}
+		user := User{}
+
+		if err := neo4jx.FetchColumn(context.Background(), runner, maxx, "end", &user); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user != other {
+			t.Errorf("\nexpected: \n\t%+v \nbut got: \n\t%+v\n", other, user)
+		}
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		runner := &fakeRunner{}
+		user := User{}
+
+		if err := neo4jx.Fetch(context.Background(), runner, maxx, &user); !errors.Is(err, neo4jx.ErrNoRecords) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", neo4jx.ErrNoRecords, err)
+		}
+	})
+}
+
+type VersionedUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version" khadijah:",version"`
+}
+
+func TestVersionConflict(t *testing.T) {
+	instance := k.New()
+	versioned := VersionedUser{ID: "1", Name: "mark", Version: 3}
+	record := map[string]interface{}{"flava": node{map[string]interface{}{"id": "1", "name": "mark", "version": int64(4)}}}
+
+	t.Run("exec", func(t *testing.T) {
+		maxx := instance.UpdateNode(versioned, &label, false)
+
+		if err := neo4jx.Exec(context.Background(), &fakeRunner{}, maxx); !errors.Is(err, k.ErrVersionConflict) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrVersionConflict, err)
+		}
+
+		if err := neo4jx.Exec(context.Background(), &fakeRunner{records: []map[string]interface{}{record}}, maxx); err != nil {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", nil, err)
+		}
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		maxx := instance.UpdateNode(versioned, &label, true)
+		updated := VersionedUser{}
+
+		if err := neo4jx.Fetch(context.Background(), &fakeRunner{}, maxx, &updated); !errors.Is(err, k.ErrVersionConflict) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrVersionConflict, err)
+		}
+
+		if err := neo4jx.Fetch(context.Background(), &fakeRunner{records: []map[string]interface{}{record}}, maxx, &updated); err != nil || updated.Version != 4 {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v %+v\n", nil, err, updated)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		maxx := instance.UpdateNodes([]VersionedUser{versioned, versioned}, &label, false)
+		runner := &fakeRunner{records: []map[string]interface{}{record}}
+
+		if err := neo4jx.Exec(context.Background(), runner, maxx); !errors.Is(err, k.ErrVersionConflict) {
+			t.Errorf("\nexpected: \n\t%v \nbut got: \n\t%v\n", k.ErrVersionConflict, err)
+		}
+	})
+}
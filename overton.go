@@ -1,8 +1,123 @@
 package khadijah
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the errors that can be found in Maxine.Err and returned by the E functions
+var (
+	ErrNilEntity         = errors.New("khadijah: entity is nil")
+	ErrNotStruct         = errors.New("khadijah: entity is not a struct")
+	ErrNoProperties      = errors.New("khadijah: entity has no tagged properties")
+	ErrEmptyLabel        = errors.New("khadijah: label is empty")
+	ErrEmptyMatchClause  = errors.New("khadijah: match clause is empty")
+	ErrMissingMatchParam = errors.New("khadijah: match clause param is missing")
+	ErrNoKeyFields       = errors.New("khadijah: no key fields were provided or tagged")
+	ErrUnboundedDelete   = errors.New("khadijah: refusing to delete every edge with the label, provide a match clause or allow unbounded deletes")
+	ErrNotPointer        = errors.New("khadijah: destination is not a non-nil pointer")
+	ErrNotRecord         = errors.New("khadijah: record does not hold properties")
+	ErrCannotHydrate     = errors.New("khadijah: value cannot be hydrated")
+	ErrInvalidVariable   = errors.New("khadijah: variable is not a valid identifier")
+	ErrUnsafeIdentifier  = errors.New("khadijah: identifier needs to be quoted")
+	ErrNotSlice          = errors.New("khadijah: entities is not a slice")
+	ErrEmptyBatch        = errors.New("khadijah: entities is empty")
+	ErrNoLabels          = errors.New("khadijah: no labels to add or remove")
+	ErrParamCollision    = errors.New("khadijah: param is already set with a different value")
+	ErrInvalidFragment   = errors.New("khadijah: fragment is not a string or *Maxine")
+	ErrInvalidPath       = errors.New("khadijah: path must alternate nodes and edges, starting and ending with a node")
+	ErrVersionConflict   = errors.New("khadijah: the entity was changed, its version does not match")
+	ErrNoChanges         = errors.New("khadijah: before and after have the same properties")
+	ErrMismatchedEntity  = errors.New("khadijah: before and after are not the same type")
+	ErrNoRemovals        = errors.New("khadijah: no properties to remove")
+	ErrInvalidHops       = errors.New("khadijah: hops are invalid")
+)
+
+// ParamCollisionError is returned when a param is merged, or renamed, into
+// params that already hold it with a different value. It is an ErrParamCollision
+type ParamCollisionError struct {
+	Param    string
+	Existing interface{}
+	Value    interface{}
+}
+
+func (e *ParamCollisionError) Error() string {
+	return fmt.Sprintf(`%s: %s is %v, not %v`, ErrParamCollision, e.Param, e.Existing, e.Value)
+}
+
+// Is reports if the target is ErrParamCollision
+func (e *ParamCollisionError) Is(target error) bool {
+	return target == ErrParamCollision
+}
+
 // M is a utility shortcut for a map
 type M map[string]interface{}
 
+// Pairs returns the entries of the map sorted by key so that a match clause
+// built from an M is the same on every call
+func (m M) Pairs() []Pair {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	pairs := make([]Pair, 0, len(keys))
+
+	for _, key := range keys {
+		pairs = append(pairs, Pair{key, m[key]})
+	}
+
+	return pairs
+}
+
+// Pair is a single key => value entry of a Clause
+type Pair struct {
+	Key   string
+	Value interface{}
+}
+
+// OM is an ordered M. Its entries are used in the order that they were defined
+//
+//	OM{{"id(+v+)", "id"}, {"+v+.name", "name"}}
+type OM []Pair
+
+// Pairs returns the entries in the order that they were defined
+func (o OM) Pairs() []Pair {
+	return o
+}
+
+// Clause is anything that can be used as a match clause, both M and OM are
+// Clauses. Each pair is a cypher expression => param name
+type Clause interface {
+	Pairs() []Pair
+}
+
+// WithLabels passes several node labels along with the entity, use it with a
+// nil label in the functions that accept a single one
+//
+//	instance.CreateNode(WithLabels(user, "Person", "Employee"), nil, false)
+func WithLabels(entity interface{}, labels ...string) Labeled {
+	return Labeled{entity, labels}
+}
+
+// joinConditions joins the conditions that aren't empty with AND
+func joinConditions(conditions ...string) string {
+	joined := []string{}
+
+	for _, condition := range conditions {
+		if condition != "" {
+			joined = append(joined, condition)
+		}
+	}
+
+	return strings.Join(joined, " AND ")
+}
+
 func Contains(items []string, key string) bool {
 	for _, s := range items {
 		if s == key {
@@ -12,3 +127,106 @@ func Contains(items []string, key string) bool {
 
 	return false
 }
+
+// IsSafeIdentifier reports if the identifier can be used in a query without
+// being quoted: a letter or underscore followed by letters, digits, or underscores
+func IsSafeIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+
+	for i, r := range identifier {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// QuoteIdentifier wraps the identifier in backticks, escaping any backticks
+// that it holds, when it isn't safe to be used as is
+//
+//	first-name => `first-name`
+func QuoteIdentifier(identifier string) string {
+	if IsSafeIdentifier(identifier) {
+		return identifier
+	}
+
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+// tagOptions is the string following a comma in a struct field's tag, or
+// the empty string. It works just like the one found in encoding/json
+type tagOptions string
+
+// parseTag splits a struct field's tag into its name and comma-separated
+// options
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+
+	return name, tagOptions(opts)
+}
+
+// Contains reports whether a comma-separated list of options contains a
+// particular option
+func (o tagOptions) Contains(option string) bool {
+	if len(o) == 0 {
+		return false
+	}
+
+	s := string(o)
+	for s != "" {
+		var name string
+		name, s, _ = strings.Cut(s, ",")
+
+		if name == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmptyValue reports if the value would be left out by encoding/json's
+// omitempty option
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+// stringValue converts scalar values to their string form for fields that
+// use the string tag option. Any other kind is returned untouched
+func stringValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.String:
+		return v.String()
+	}
+
+	return v.Interface()
+}
@@ -1,8 +1,12 @@
 package khadijah
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
-func newRegine(matchClause M, rootMaxx *Maxine) *regine {
+func newRegine(matchClause Clause, rootMaxx *Maxine) *regine {
 	return &regine{
 		matchClause: matchClause,
 		rootMaxx:    rootMaxx,
@@ -10,31 +14,29 @@ func newRegine(matchClause M, rootMaxx *Maxine) *regine {
 }
 
 type regine struct {
-	matchClause M
+	matchClause Clause
 	rootMaxx    *Maxine
 }
 
 func (r *regine) nodeWithProperties(entity interface{}, label *string) *Maxine {
 	maxx := r.rootMaxx.Parse(entity)
+	maxx.checkProperties()
+	nodeLabel := maxx.labels(label)
 
-	if label == nil {
-		label = &maxx.EntityName
-	}
-
-	maxx.Query = fmt.Sprintf(`(%s:%s %s)`, maxx.Variable, *label, maxx.CreateQuery)
+	maxx.Query = fmt.Sprintf(`(%s:%s %s)`, maxx.Variable, nodeLabel, maxx.patternQuery())
 
 	return maxx
 }
 
-func (r *regine) matchNodeWithMatch(entity interface{}, label *string, matchClause M, withReturn bool) *Maxine {
+func (r *regine) matchNodeWithMatch(entity interface{}, label *string, matchClause Clause, withReturn bool) *Maxine {
 	maxx := r.rootMaxx.Parse(entity)
+	maxx.checkProperties()
 	maxx.ParseMatchClause(matchClause)
+	maxx.checkMatchClause(matchClause)
+	maxx.excludeDeleted()
+	nodeLabel := maxx.labels(label)
 
-	if label == nil {
-		label = &maxx.EntityName
-	}
-
-	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s`, maxx.Variable, *label, maxx.MatchClause)
+	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s`, maxx.Variable, nodeLabel, maxx.MatchClause)
 
 	if withReturn {
 		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
@@ -50,59 +52,265 @@ func (r *regine) matchNode(entity interface{}, label *string, withReturn bool) *
 // CREATE (x:Label {param: $param}) RETURN x
 func (r *regine) createNode(entity interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
 	maxx := r.rootMaxx.Parse(entity, excludes...)
+	maxx.checkProperties()
+	nodeLabel := maxx.labels(label)
 
-	if label == nil {
-		label = &maxx.EntityName
-	}
+	maxx.Query = fmt.Sprintf(`CREATE (%s:%s %s)`, maxx.Variable, nodeLabel, maxx.CreateQuery)
 
-	maxx.Query = fmt.Sprintf(`CREATE (%s:%s %s)`, maxx.Variable, *label, maxx.CreateQuery)
+	if maxx.mapped() {
+		maxx.Query = fmt.Sprintf(`CREATE (%s:%s) SET %s`, maxx.Variable, nodeLabel, maxx.mapClause(maxx.GetTag(PropsParam), "=", false))
+	}
 
 	if withReturn {
 		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
 	}
 
+	maxx.trimParams()
+
 	return maxx
 }
 
-// MERGE (x:Label {param: $param}) SET param1 = $param1 RETURN x
-func (r *regine) updateNodeWithMatch(entity interface{}, label *string, matchClause M, withReturn bool, excludes ...string) *Maxine {
+// MATCH (x:Label) WHERE id(x) = $id SET x.param1 = $param1 RETURN x
+func (r *regine) updateNodeWithMatch(entity interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) *Maxine {
 	maxx := r.rootMaxx.Parse(entity, excludes...)
+	maxx.checkProperties()
 	maxx.ParseMatchClause(matchClause)
+	maxx.checkMatchClause(matchClause)
+	maxx.excludeDeleted()
+	maxx.MatchClause = joinConditions(maxx.MatchClause, maxx.versionCondition("$"))
+	maxx.useMapClause()
+	nodeLabel := maxx.labels(label)
 
-	if label == nil {
-		label = &maxx.EntityName
-	}
+	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s`, maxx.Variable, nodeLabel, maxx.MatchClause)
 
-	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s SET %s`, maxx.Variable, *label, maxx.MatchClause, maxx.SetQuery)
+	if clauses := maxx.updateClauses(); clauses != "" {
+		maxx.Query = fmt.Sprintf(`%s %s`, maxx.Query, clauses)
+	}
 
-	if withReturn {
+	// versioned updates always return the node so that conflicts can be detected
+	if withReturn || maxx.Versioned {
 		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
 	}
 
+	maxx.trimParams()
+
 	return maxx
 }
 
-// MERGE (x:Label {id: $id}) SET param1 = $param1 RETURN x
+// MATCH (x:Label) WHERE id(x) = $id SET x.param1 = $param1 RETURN x
 func (r *regine) updateNode(entity interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
 	return r.updateNodeWithMatch(entity, label, r.matchClause, withReturn, excludes...)
 }
 
+// MATCH (x:Label) WHERE id(x) = $id SET x.changed = $changed REMOVE x.removed RETURN x
+// only the properties that changed between before and after are SET. The ones
+// that after left out, or that became nil, are REMOVEd
+func (r *regine) updateNodeDiffWithMatch(before, after interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) *Maxine {
+	previous := r.rootMaxx.Parse(before, excludes...)
+	maxx := r.rootMaxx.Parse(after, excludes...)
+	maxx.setErr(previous.Err)
+	maxx.checkProperties()
+	maxx.ParseMatchClause(matchClause)
+	maxx.checkMatchClause(matchClause)
+	maxx.excludeDeleted()
+	nodeLabel := maxx.labels(label)
+
+	if reflect.TypeOf(before) != reflect.TypeOf(after) {
+		maxx.setErr(fmt.Errorf(`%w: %T and %T`, ErrMismatchedEntity, before, after))
+	}
+
+	updatable := func(prop Property) bool {
+		return !prop.Excluded && !prop.ReadOnly && !prop.Key
+	}
+	sets := []string{}
+	removes := []string{}
+	managed := []string{}
+	changes := map[string]interface{}{}
+
+	for _, prop := range maxx.Properties {
+		if !updatable(prop) {
+			continue
+		}
+
+		// the version and timestamps are only SET along with other changes
+		if prop.Version || prop.Timestamp {
+			managed = append(managed, maxx.setEntry(prop))
+			continue
+		}
+
+		old, ok := previous.property(prop.Name)
+
+		switch {
+		case prop.Value == nil && (!ok || old.Value == nil):
+		case prop.Value == nil:
+			removes = append(removes, maxx.removeEntry(prop.Name))
+			changes[prop.Name] = nil
+		case !ok || !reflect.DeepEqual(old.Value, prop.Value):
+			sets = append(sets, maxx.setEntry(prop))
+			changes[prop.Name] = prop.Value
+		}
+	}
+
+	for _, prop := range previous.Properties {
+		if _, ok := maxx.property(prop.Name); ok || !updatable(prop) {
+			continue
+		}
+
+		removes = append(removes, maxx.removeEntry(prop.Name))
+		changes[prop.Name] = nil
+	}
+
+	// += REMOVEs the nulls in the map
+	if maxx.mapped() && len(changes) > 0 {
+		param := maxx.GetTag(PropsParam)
+		maxx.Params[param] = changes
+		sets = []string{fmt.Sprintf(`%s += %s`, maxx.Variable, maxx.placeholder(param))}
+		removes = nil
+	}
+
+	if len(sets) == 0 && len(removes) == 0 {
+		maxx.setErr(ErrNoChanges)
+	} else {
+		sets = append(sets, managed...)
+		maxx.MatchClause = joinConditions(maxx.MatchClause, maxx.versionCondition("$"))
+	}
+
+	maxx.SetQuery = strings.Join(sets, ", ")
+	maxx.RemoveQuery = strings.Join(removes, ", ")
+	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s`, maxx.Variable, nodeLabel, maxx.MatchClause)
+
+	if clauses := maxx.updateClauses(); clauses != "" {
+		maxx.Query = fmt.Sprintf(`%s %s`, maxx.Query, clauses)
+	}
+
+	if withReturn || maxx.Versioned {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
+	}
+
+	maxx.trimParams()
+
+	return maxx
+}
+
+// MATCH (x:Label) WHERE id(x) = $id REMOVE x.prop1, x.prop2
+// a versioned node also has its version checked and incremented
+func (r *regine) removePropertiesWithMatch(entity interface{}, label *string, matchClause Clause, props ...string) *Maxine {
+	maxx := r.rootMaxx.Parse(entity)
+	maxx.checkProperties()
+	maxx.ParseMatchClause(matchClause)
+	maxx.checkMatchClause(matchClause)
+	maxx.excludeDeleted()
+	maxx.MatchClause = joinConditions(maxx.MatchClause, maxx.versionCondition("$"))
+	nodeLabel := maxx.labels(label)
+
+	if len(props) == 0 {
+		maxx.setErr(ErrNoRemovals)
+	}
+
+	removes := make([]string, 0, len(props))
+
+	for _, prop := range props {
+		removes = append(removes, maxx.removeEntry(prop))
+	}
+
+	sets := []string{}
+
+	for _, prop := range maxx.Properties {
+		if prop.Version && !prop.Excluded {
+			sets = append(sets, maxx.setEntry(prop))
+		}
+	}
+
+	maxx.SetQuery = strings.Join(sets, ", ")
+	maxx.RemoveQuery = strings.Join(removes, ", ")
+	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s`, maxx.Variable, nodeLabel, maxx.MatchClause)
+
+	if clauses := maxx.updateClauses(); clauses != "" {
+		maxx.Query = fmt.Sprintf(`%s %s`, maxx.Query, clauses)
+	}
+
+	if maxx.Versioned {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
+	}
+
+	return maxx
+}
+
+// MERGE (x:Label {key: $key}) ON CREATE SET x.param = $param ON MATCH SET x.param = $param RETURN x
+// when keyFields is empty the properties tagged as key are used. readonly
+// properties are only set on create
+func (r *regine) upsertNode(entity interface{}, label *string, keyFields []string, withReturn bool, excludes ...string) *Maxine {
+	maxx := r.rootMaxx.Parse(entity, excludes...)
+	maxx.checkProperties()
+	nodeLabel := maxx.labels(label)
+	keys, onSet := maxx.mergeClauses(keyFields)
+
+	maxx.Query = fmt.Sprintf(`MERGE (%s:%s {%s})%s`, maxx.Variable, nodeLabel, keys, onSet)
+
+	if withReturn {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
+	}
+
+	maxx.trimParams()
+
+	return maxx
+}
+
+// MATCH (x:Label) WHERE id(x) = $id SET x:Added REMOVE x:Removed RETURN x
+func (r *regine) updateLabelsWithMatch(entity interface{}, label *string, matchClause Clause, add, remove []string, withReturn bool) *Maxine {
+	maxx := r.rootMaxx.Parse(entity)
+	maxx.checkProperties()
+	maxx.ParseMatchClause(matchClause)
+	maxx.checkMatchClause(matchClause)
+	maxx.excludeDeleted()
+	nodeLabel := maxx.labels(label)
+
+	if len(add) == 0 && len(remove) == 0 {
+		maxx.setErr(ErrNoLabels)
+	}
+
+	maxx.Query = fmt.Sprintf(`MATCH (%s:%s) WHERE %s`, maxx.Variable, nodeLabel, maxx.MatchClause)
+
+	if len(add) > 0 {
+		maxx.Query = fmt.Sprintf(`%s SET %s:%s`, maxx.Query, maxx.Variable, maxx.joinLabels(add))
+	}
+
+	if len(remove) > 0 {
+		maxx.Query = fmt.Sprintf(`%s REMOVE %s:%s`, maxx.Query, maxx.Variable, maxx.joinLabels(remove))
+	}
+
+	if withReturn {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
+	}
+
+	return maxx
+}
+
 // MATCH (x {param: $param}) [DETACH] DELETE x
-func (r *regine) deleteNodeWithMatch(entity interface{}, detach bool, matchClause M) *Maxine {
+func (r *regine) deleteNodeWithMatch(entity interface{}, detach bool, matchClause Clause) *Maxine {
 	detachClause := " "
 	if detach {
 		detachClause = " DETACH "
 	}
 
 	maxx := r.rootMaxx.Parse(entity)
+	maxx.checkProperties()
 	maxx.ParseMatchClause(matchClause)
+	maxx.checkMatchClause(matchClause)
+
+	// soft deletes leave the node and its edges in place
+	if maxx.SoftDeleteProperty != "" {
+		maxx.excludeDeleted()
+		maxx.Query = fmt.Sprintf(`MATCH (%s) WHERE %s %s`, maxx.Variable, maxx.MatchClause, maxx.softDelete(true))
+		return maxx
+	}
 
 	maxx.Query = fmt.Sprintf(`MATCH (%s) WHERE %s%sDELETE %s`, maxx.Variable, maxx.MatchClause, detachClause, maxx.Variable)
 	return maxx
 }
 
 // MATCH (x {param: $param}) [DETACH] DELETE x
-func (r *regine) detachDeleteNodeWithMatch(entity interface{}, matchClause M) *Maxine {
+func (r *regine) detachDeleteNodeWithMatch(entity interface{}, matchClause Clause) *Maxine {
 	return r.deleteNodeWithMatch(entity, true, matchClause)
 }
 
@@ -115,3 +323,89 @@ func (r *regine) detachDeleteNode(entity interface{}) *Maxine {
 func (r *regine) deleteNode(entity interface{}, detach bool) *Maxine {
 	return r.deleteNodeWithMatch(entity, detach, r.matchClause)
 }
+
+// UNWIND $rows AS row CREATE (x:Label) SET x = row RETURN x
+func (r *regine) createNodes(entities interface{}, label *string, withReturn bool, excludes ...string) *Maxine {
+	maxx, parsed := r.rootMaxx.parseBatch(entities, excludes...)
+	maxx.checkProperties()
+	nodeLabel := maxx.labels(label)
+	rows := make([]interface{}, 0, len(parsed))
+
+	for _, item := range parsed {
+		rows = append(rows, item.propertyMap(false))
+	}
+
+	// without a Clock, the timestamps are set by the database like they are for a single node
+	setClause := strings.Join(append([]string{fmt.Sprintf(`%s = %s`, maxx.Variable, RowVariable)}, maxx.mapEntries(false)...), ", ")
+
+	maxx.Params[maxx.GetTag(RowsParam)] = rows
+	maxx.Query = fmt.Sprintf(`UNWIND %s AS %s CREATE (%s:%s) SET %s`, maxx.placeholder(maxx.GetTag(RowsParam)), RowVariable, maxx.Variable, nodeLabel, setClause)
+
+	if withReturn {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
+	}
+
+	return maxx
+}
+
+// UNWIND $rows AS row MATCH (x:Label) WHERE id(x) = row.match.id SET x += row.props RETURN x
+func (r *regine) updateNodesWithMatch(entities interface{}, label *string, matchClause Clause, withReturn bool, excludes ...string) *Maxine {
+	maxx, parsed := r.rootMaxx.parseBatch(entities, excludes...)
+	maxx.checkProperties()
+	maxx.parseMatchClause(matchClause, RowVariable+".match.")
+	maxx.excludeDeleted()
+	maxx.MatchClause = joinConditions(maxx.MatchClause, maxx.versionCondition(RowVariable+".match."))
+	nodeLabel := maxx.labels(label)
+	setClause := strings.Join(append([]string{fmt.Sprintf(`%s += %s.props`, maxx.Variable, RowVariable)}, maxx.mapEntries(true)...), ", ")
+	rows := make([]interface{}, 0, len(parsed))
+
+	for _, item := range parsed {
+		item.checkMatchClause(matchClause)
+		maxx.setErr(item.Err)
+		rows = append(rows, map[string]interface{}{
+			"match": item.matchParams(matchClause, true),
+			"props": item.propertyMap(true),
+		})
+	}
+
+	maxx.Params[maxx.GetTag(RowsParam)] = rows
+
+	maxx.Query = fmt.Sprintf(`UNWIND %s AS %s MATCH (%s:%s) WHERE %s SET %s`, maxx.placeholder(maxx.GetTag(RowsParam)), RowVariable, maxx.Variable, nodeLabel, maxx.MatchClause, setClause)
+
+	if withReturn || maxx.Versioned {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, maxx.Variable)
+	}
+
+	return maxx
+}
+
+// UNWIND $rows AS row MATCH (x) WHERE id(x) = row.id [DETACH] DELETE x
+func (r *regine) deleteNodesWithMatch(entities interface{}, detach bool, matchClause Clause) *Maxine {
+	detachClause := " "
+	if detach {
+		detachClause = " DETACH "
+	}
+
+	maxx, parsed := r.rootMaxx.parseBatch(entities)
+	maxx.checkProperties()
+	maxx.parseMatchClause(matchClause, RowVariable+".")
+	rows := make([]interface{}, 0, len(parsed))
+
+	for _, item := range parsed {
+		item.checkMatchClause(matchClause)
+		maxx.setErr(item.Err)
+		rows = append(rows, item.matchParams(matchClause, false))
+	}
+
+	maxx.Params[maxx.GetTag(RowsParam)] = rows
+
+	if maxx.SoftDeleteProperty != "" {
+		maxx.excludeDeleted()
+		maxx.Query = fmt.Sprintf(`UNWIND %s AS %s MATCH (%s) WHERE %s %s`, maxx.placeholder(maxx.GetTag(RowsParam)), RowVariable, maxx.Variable, maxx.MatchClause, maxx.softDelete(true))
+		return maxx
+	}
+
+	maxx.Query = fmt.Sprintf(`UNWIND %s AS %s MATCH (%s) WHERE %s%sDELETE %s`, maxx.placeholder(maxx.GetTag(RowsParam)), RowVariable, maxx.Variable, maxx.MatchClause, detachClause, maxx.Variable)
+
+	return maxx
+}
@@ -1,21 +1,26 @@
 package khadijah
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-func newSynclaire(matchClause M, startVariable, endVariable string, rootMaxx *Maxine) *synclarie {
+func newSynclaire(matchClause Clause, startVariable, endVariable string, allowUnboundedDelete bool, rootMaxx *Maxine) *synclarie {
 	return &synclarie{
-		matchClause:   matchClause,
-		startVariable: startVariable,
-		endVariable:   endVariable,
-		rootMaxx:      rootMaxx,
+		matchClause:          matchClause,
+		startVariable:        startVariable,
+		endVariable:          endVariable,
+		allowUnboundedDelete: allowUnboundedDelete,
+		rootMaxx:             rootMaxx,
 	}
 }
 
 type synclarie struct {
-	matchClause   M
-	startVariable string
-	endVariable   string
-	rootMaxx      *Maxine
+	matchClause          Clause
+	startVariable        string
+	endVariable          string
+	allowUnboundedDelete bool
+	rootMaxx             *Maxine
 }
 
 func (s *synclarie) getDirection(direction string) (dirStart, dirEnd string) {
@@ -36,107 +41,621 @@ func (s *synclarie) getDirection(direction string) (dirStart, dirEnd string) {
 	return dirStart, dirEnd
 }
 
-func (s *synclarie) createEdgeWithMatches(start interface{}, startLabel *string, startMatchClause M, direction string, end interface{}, endLabel *string, endMatchClause M, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) *Maxine {
-	khadStart := New(
-		SetVariable("start"),
-		SetParamPrefix("start_"),
-		SetMatchClause(startMatchClause),
-	)
-	khadEnd := New(
-		SetVariable("end"),
-		SetParamPrefix("end_"),
-		SetMatchClause(endMatchClause),
-	)
-	nodeStart := khadStart.MatchNode(start, startLabel, false)
-	nodeEnd := khadEnd.MatchNode(end, endLabel, false)
+// matchNodes builds the MATCH queries for the start and end nodes of an edge.
+// The start params are prefixed with "startVariable_" and the end params with "endVariable_"
+func (s *synclarie) matchNodes(start interface{}, startLabel *string, startMatchClause Clause, end interface{}, endLabel *string, endMatchClause Clause) (nodeStart, nodeEnd *Maxine) {
+	startRoot := s.rootMaxx.derive(s.startVariable, s.startVariable+"_", startMatchClause)
+	endRoot := s.rootMaxx.derive(s.endVariable, s.endVariable+"_", endMatchClause)
+	nodeStart = newRegine(startMatchClause, startRoot).matchNode(start, startLabel, false)
+	nodeEnd = newRegine(endMatchClause, endRoot).matchNode(end, endLabel, false)
+
+	return nodeStart, nodeEnd
+}
+
+// MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id CREATE (start)-[edge:label {props}]->(end) RETURN start, edge, end
+func (s *synclarie) createEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, withReturn bool, excldues ...string) *Maxine {
+	nodeStart, nodeEnd := s.matchNodes(start, startLabel, startMatchClause, end, endLabel, endMatchClause)
 	dirStart, dirEnd := s.getDirection(direction)
-	maxx := s.rootMaxx.Parse(edge)
-	labelEdge := maxx.EntityName
-	if edgeLabel != nil {
-		labelEdge = *edgeLabel
-	}
+	maxx := s.rootMaxx.Parse(edge, excldues...)
+	maxx.setErr(nodeStart.Err)
+	maxx.setErr(nodeEnd.Err)
+	labelEdge := maxx.label(edgeLabel)
 
 	maxx.Query = fmt.Sprintf(`%s %s CREATE (%s)%s[%s:%s %s]%s(%s)`,
 		nodeStart.Query,
 		nodeEnd.Query,
-		khadStart.StartVariable,
+		s.startVariable,
 		dirStart,
 		maxx.Variable,
 		labelEdge,
 		maxx.CreateQuery,
 		dirEnd,
-		khadEnd.EndVariable)
+		s.endVariable)
+
+	if maxx.mapped() {
+		maxx.Query = fmt.Sprintf(`%s %s CREATE (%s)%s[%s:%s]%s(%s) SET %s`,
+			nodeStart.Query,
+			nodeEnd.Query,
+			s.startVariable,
+			dirStart,
+			maxx.Variable,
+			labelEdge,
+			dirEnd,
+			s.endVariable,
+			maxx.mapClause(maxx.GetTag(PropsParam), "=", false))
+	}
 
 	maxx.MergeParams(nodeStart.Params, nodeEnd.Params)
 
 	if withReturn {
-		maxx.Query = fmt.Sprintf(`%s RETURN %s, %s, %s`, maxx.Query, khadStart.Variable, maxx.Variable, khadEnd.Variable)
+		maxx.Query = fmt.Sprintf(`%s RETURN %s, %s, %s`, maxx.Query, s.startVariable, maxx.Variable, s.endVariable)
+	}
+
+	maxx.trimParams()
+
+	return maxx
+}
+
+// MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MATCH (start)-[edge:label]->(end) WHERE id(edge) = $id SET edge.param = $param RETURN start, edge, end
+// when the edgeMatchClause is empty every edge of that label between the two nodes is updated
+func (s *synclarie) updateEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, edgeMatchClause Clause, withReturn bool, excldues ...string) *Maxine {
+	nodeStart, nodeEnd := s.matchNodes(start, startLabel, startMatchClause, end, endLabel, endMatchClause)
+	dirStart, dirEnd := s.getDirection(direction)
+	maxx := s.rootMaxx.Parse(edge, excldues...)
+	maxx.setErr(nodeStart.Err)
+	maxx.setErr(nodeEnd.Err)
+	maxx.checkProperties()
+	labelEdge := maxx.label(edgeLabel)
+
+	maxx.Query = fmt.Sprintf(`%s %s MATCH (%s)%s[%s:%s]%s(%s)`,
+		nodeStart.Query,
+		nodeEnd.Query,
+		s.startVariable,
+		dirStart,
+		maxx.Variable,
+		labelEdge,
+		dirEnd,
+		s.endVariable)
+
+	where := joinConditions(maxx.notDeleted(maxx.Variable), maxx.versionCondition("$"))
+
+	if edgeMatchClause != nil && len(edgeMatchClause.Pairs()) > 0 {
+		maxx.ParseMatchClause(edgeMatchClause)
+		maxx.checkMatchClause(edgeMatchClause)
+		where = joinConditions(maxx.MatchClause, where)
 	}
 
+	if where != "" {
+		maxx.Query = fmt.Sprintf(`%s WHERE %s`, maxx.Query, where)
+	}
+
+	maxx.useMapClause()
+
+	if clauses := maxx.updateClauses(); clauses != "" {
+		maxx.Query = fmt.Sprintf(`%s %s`, maxx.Query, clauses)
+	}
+
+	maxx.MergeParams(nodeStart.Params, nodeEnd.Params)
+
+	if withReturn || maxx.Versioned {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s, %s, %s`, maxx.Query, s.startVariable, maxx.Variable, s.endVariable)
+	}
+
+	maxx.trimParams()
+
 	return maxx
 }
 
-func (s *synclarie) updateEdgeWithMatches(start interface{}, startLabel *string, startMatchClause M, direction string, end interface{}, endLabel *string, endMatchClause M, edge interface{}, edgeLabel *string, edgeMatchClause M, withReturn bool, excldues ...string) *Maxine {
-	khadStart := New(
-		SetVariable("start"),
-		SetParamPrefix("start"),
-		SetMatchClause(startMatchClause),
-	)
-	khadEnd := New(
-		SetVariable("end"),
-		SetParamPrefix("end"),
-		SetMatchClause(endMatchClause),
-	)
-	nodeStart := khadStart.NodeWithProperties(start, startLabel)
-	nodeEnd := khadEnd.NodeWithProperties(start, endLabel)
+// MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MERGE (start)-[edge:label {key: $key}]->(end) ON CREATE SET edge.param = $param ON MATCH SET edge.param = $param RETURN start, edge, end
+// when keyFields is empty the properties tagged as key are used
+func (s *synclarie) upsertEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, keyFields []string, withReturn bool, excldues ...string) *Maxine {
+	nodeStart, nodeEnd := s.matchNodes(start, startLabel, startMatchClause, end, endLabel, endMatchClause)
 	dirStart, dirEnd := s.getDirection(direction)
-	maxx := s.rootMaxx.Parse(edge)
-	maxx.Query = fmt.Sprintf(`MATCH %s, %s MERGE (%s)%s[%s:%s %s]%s(%s) SET %s`,
+	maxx := s.rootMaxx.Parse(edge, excldues...)
+	maxx.setErr(nodeStart.Err)
+	maxx.setErr(nodeEnd.Err)
+	maxx.checkProperties()
+	labelEdge := maxx.label(edgeLabel)
+	keys, onSet := maxx.mergeClauses(keyFields)
+
+	maxx.Query = fmt.Sprintf(`%s %s MERGE (%s)%s[%s:%s {%s}]%s(%s)%s`,
 		nodeStart.Query,
 		nodeEnd.Query,
-		khadStart.StartVariable,
+		s.startVariable,
 		dirStart,
 		maxx.Variable,
-		*edgeLabel,
-		edgeMatchClause,
+		labelEdge,
+		keys,
 		dirEnd,
-		khadEnd.EndVariable,
-		maxx.SetQuery,
-	)
+		s.endVariable,
+		onSet)
 
 	maxx.MergeParams(nodeStart.Params, nodeEnd.Params)
 
 	if withReturn {
-		maxx.Query = fmt.Sprintf(`%s RETURN %s, %s, %s`, maxx.Query, khadStart.Variable, maxx.Variable, khadEnd.Variable)
+		maxx.Query = fmt.Sprintf(`%s RETURN %s, %s, %s`, maxx.Query, s.startVariable, maxx.Variable, s.endVariable)
 	}
 
+	maxx.trimParams()
+
 	return maxx
 }
 
-func (s *synclarie) deleteEdgeWithMatchingLabels(startLabel, direction, endLabel string, edge interface{}, edgeLabel string, edgeMatchClause M) *Maxine {
+// MATCH (:StartLabel)-[edge:label]->(:EndLabel) WHERE edge.param = $param DELETE edge
+func (s *synclarie) deleteEdgeWithMatchingLabels(startLabel, direction, endLabel string, edge interface{}, edgeLabel string, edgeMatchClause Clause) *Maxine {
+	dirStart, dirEnd := s.getDirection(direction)
+	maxx := s.parseEdge(edge)
+	labelEdge := maxx.label(&edgeLabel)
+	maxx.Query = fmt.Sprintf(`MATCH (:%s)%s[%s:%s]%s(:%s)`,
+		maxx.labels(&startLabel),
+		dirStart,
+		maxx.Variable,
+		labelEdge,
+		dirEnd,
+		maxx.labels(&endLabel))
+
+	return s.deleteMatchedEdge(maxx, edgeMatchClause, false)
+}
+
+// MATCH ()-[edge:label]->() WHERE edge.param = $param DELETE edge
+func (s *synclarie) deleteEdge(edge interface{}, edgeLabel, direction string, edgeMatchClause Clause) *Maxine {
+	dirStart, dirEnd := s.getDirection(direction)
+	maxx := s.parseEdge(edge)
+	labelEdge := maxx.label(&edgeLabel)
+	maxx.Query = fmt.Sprintf(`MATCH ()%s[%s:%s]%s()`,
+		dirStart,
+		maxx.Variable,
+		labelEdge,
+		dirEnd)
+
+	return s.deleteMatchedEdge(maxx, edgeMatchClause, false)
+}
+
+// MATCH (start:Label) WHERE id(start) = $start_id MATCH (end:Label) WHERE id(end) = $end_id MATCH (start)-[edge:label]->(end) WHERE edge.param = $param DELETE edge
+// the edge entity is optional, without it every edge of that label between the nodes is deleted
+func (s *synclarie) deleteEdgeWithMatches(start interface{}, startLabel *string, startMatchClause Clause, direction string, end interface{}, endLabel *string, endMatchClause Clause, edge interface{}, edgeLabel *string, edgeMatchClause Clause) *Maxine {
+	nodeStart, nodeEnd := s.matchNodes(start, startLabel, startMatchClause, end, endLabel, endMatchClause)
 	dirStart, dirEnd := s.getDirection(direction)
-	maxx := s.rootMaxx.Parse(edge)
-	maxx.Query = fmt.Sprintf(`MATCH (:%s)%s(%s:%s)%s(:%s) DELETE %s`,
-		startLabel,
+	maxx := s.parseEdge(edge)
+	maxx.setErr(nodeStart.Err)
+	maxx.setErr(nodeEnd.Err)
+	labelEdge := maxx.label(edgeLabel)
+	maxx.Query = fmt.Sprintf(`%s %s MATCH (%s)%s[%s:%s]%s(%s)`,
+		nodeStart.Query,
+		nodeEnd.Query,
+		s.startVariable,
 		dirStart,
 		maxx.Variable,
-		edgeLabel,
+		labelEdge,
 		dirEnd,
-		edgeLabel,
-		maxx.Variable)
+		s.endVariable)
+
+	maxx.MergeParams(nodeStart.Params, nodeEnd.Params)
+
+	return s.deleteMatchedEdge(maxx, edgeMatchClause, true)
+}
+
+// parseEdge parses the edge entity. A nil edge is allowed and will produce a
+// Maxine without any properties
+func (s *synclarie) parseEdge(edge interface{}) *Maxine {
+	if edge == nil {
+		return s.rootMaxx.derive(s.rootMaxx.Variable, s.rootMaxx.ParamPefix, s.rootMaxx.DefaultMatchClause)
+	}
+
+	return s.rootMaxx.Parse(edge)
+}
+
+// deleteMatchedEdge adds the edge's WHERE and DELETE clauses to the query. An
+// edge that isn't bound by its nodes or its own match clause would delete
+// every edge with that label, that query is refused unless it was explicitly allowed
+func (s *synclarie) deleteMatchedEdge(maxx *Maxine, edgeMatchClause Clause, bound bool) *Maxine {
+	where := ""
+
+	if edgeMatchClause != nil && len(edgeMatchClause.Pairs()) > 0 {
+		maxx.ParseMatchClause(edgeMatchClause)
+		maxx.checkMatchClause(edgeMatchClause)
+		where = maxx.MatchClause
+		bound = true
+	}
+
+	if !bound && !s.allowUnboundedDelete {
+		maxx.setErr(ErrUnboundedDelete)
+		maxx.Query = ""
+
+		return maxx
+	}
+
+	if maxx.SoftDeleteProperty != "" {
+		where = joinConditions(where, maxx.notDeleted(maxx.Variable))
+	}
+
+	if where != "" {
+		maxx.Query = fmt.Sprintf(`%s WHERE %s`, maxx.Query, where)
+	}
+
+	if maxx.SoftDeleteProperty != "" {
+		maxx.Query = fmt.Sprintf(`%s %s`, maxx.Query, maxx.softDelete(false))
+		return maxx
+	}
+
+	maxx.Query = fmt.Sprintf(`%s DELETE %s`, maxx.Query, maxx.Variable)
 
 	return maxx
 }
 
-func (s *synclarie) deleteEdge(edge interface{}, edgeLabel, direction string, edgeMatchClause M) *Maxine {
+// Link holds the entities that make up a single edge in the batch edge functions
+type Link struct {
+	Start interface{}
+	End   interface{}
+	Edge  interface{}
+}
+
+// UNWIND $rows AS row MATCH (start:Label) WHERE id(start) = row.start.id MATCH (end:Label) WHERE id(end) = row.end.id CREATE (start)-[edge:label]->(end) SET edge = row.edge RETURN start, edge, end
+func (s *synclarie) createEdgesWithMatches(links []Link, startLabel *string, startMatchClause Clause, direction string, endLabel *string, endMatchClause Clause, edgeLabel *string, withReturn bool, excludes ...string) *Maxine {
+	starts := make([]interface{}, 0, len(links))
+	ends := make([]interface{}, 0, len(links))
+	edges := make([]interface{}, 0, len(links))
+
+	for _, link := range links {
+		starts = append(starts, link.Start)
+		ends = append(ends, link.End)
+		edges = append(edges, link.Edge)
+	}
+
+	startRoot := s.rootMaxx.derive(s.startVariable, s.rootMaxx.ParamPefix, startMatchClause)
+	endRoot := s.rootMaxx.derive(s.endVariable, s.rootMaxx.ParamPefix, endMatchClause)
+	startBatch, startParsed := startRoot.parseBatch(starts)
+	endBatch, endParsed := endRoot.parseBatch(ends)
+	maxx, edgeParsed := s.rootMaxx.parseBatch(edges, excludes...)
+	startBatch.parseMatchClause(startMatchClause, RowVariable+".start.")
+	endBatch.parseMatchClause(endMatchClause, RowVariable+".end.")
+	startBatch.excludeDeleted()
+	endBatch.excludeDeleted()
+
+	nodeStartLabel := startBatch.labels(startLabel)
+	nodeEndLabel := endBatch.labels(endLabel)
+	labelEdge := maxx.label(edgeLabel)
+	maxx.setErr(startBatch.Err)
+	maxx.setErr(endBatch.Err)
+
+	rows := make([]interface{}, 0, len(edgeParsed))
+
+	for i, item := range edgeParsed {
+		startParsed[i].checkMatchClause(startMatchClause)
+		endParsed[i].checkMatchClause(endMatchClause)
+		maxx.setErr(startParsed[i].Err)
+		maxx.setErr(endParsed[i].Err)
+		rows = append(rows, map[string]interface{}{
+			"start": startParsed[i].matchParams(startMatchClause, false),
+			"end":   endParsed[i].matchParams(endMatchClause, false),
+			"edge":  item.propertyMap(false),
+		})
+	}
+
 	dirStart, dirEnd := s.getDirection(direction)
-	maxx := s.rootMaxx.Parse(edge)
-	maxx.Query = fmt.Sprintf(`MATCH ()%s(%s:%s)%s() DELETE %s`,
+	setClause := strings.Join(append([]string{fmt.Sprintf(`%s = %s.edge`, maxx.Variable, RowVariable)}, maxx.mapEntries(false)...), ", ")
+	maxx.Params[maxx.GetTag(RowsParam)] = rows
+	maxx.Query = fmt.Sprintf(`UNWIND %s AS %s MATCH (%s:%s) WHERE %s MATCH (%s:%s) WHERE %s CREATE (%s)%s[%s:%s]%s(%s) SET %s`,
+		maxx.placeholder(maxx.GetTag(RowsParam)),
+		RowVariable,
+		s.startVariable,
+		nodeStartLabel,
+		startBatch.MatchClause,
+		s.endVariable,
+		nodeEndLabel,
+		endBatch.MatchClause,
+		s.startVariable,
 		dirStart,
 		maxx.Variable,
-		edgeLabel,
+		labelEdge,
 		dirEnd,
-		maxx.Variable)
+		s.endVariable,
+		setClause)
+
+	if withReturn {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s, %s, %s`, maxx.Query, s.startVariable, maxx.Variable, s.endVariable)
+	}
+
+	return maxx
+}
+
+// PathElement is a node or an edge in a path. Use PathNode and PathEdge to create them
+type PathElement struct {
+	Entity    interface{}
+	Label     *string
+	Direction string
+	IsEdge    bool
+
+	// the conditions that a MATCH uses to find the entity when it has no
+	// key properties. The default match clause is used when it is nil
+	MatchClause Clause
+}
+
+// PathNode creates a node element for a path. The entity can be nil when the
+// label is provided
+func PathNode(entity interface{}, label *string) PathElement {
+	return PathElement{Entity: entity, Label: label}
+}
+
+// PathNodeWithMatch creates a node element that MatchPath finds with the matchClause
+func PathNodeWithMatch(entity interface{}, label *string, matchClause Clause) PathElement {
+	return PathElement{Entity: entity, Label: label, MatchClause: matchClause}
+}
+
+// PathEdge creates an edge element for a path that points in the direction,
+// "in" or "out", from the node before it. The entity can be nil when the label is provided
+func PathEdge(entity interface{}, label *string, direction string) PathElement {
+	return PathElement{Entity: entity, Label: label, Direction: direction, IsEdge: true}
+}
+
+// PathEdgeWithMatch creates an edge element that MatchPath finds with the matchClause
+func PathEdgeWithMatch(entity interface{}, label *string, direction string, matchClause Clause) PathElement {
+	return PathElement{Entity: entity, Label: label, Direction: direction, IsEdge: true, MatchClause: matchClause}
+}
+
+// KEYWORD (n0:Label {keys})-[r0:label]->(n1:Label {keys}) RETURN n0, r0, n1
+// nodes use the variables n0, n1, ... and edges r0, r1, ... each element's
+// params are prefixed with its variable: $n0_id
+//
+// MATCH patterns only hold the key properties, entities without them are found
+// with their match clause in the WHERE. MERGE patterns hold the key properties
+// and the rest are SET with ON CREATE SET and ON MATCH SET. CREATE writes every property
+func (s *synclarie) path(keyword string, elements []PathElement, withReturn bool) *Maxine {
+	maxx := s.rootMaxx.derive(s.rootMaxx.Variable, s.rootMaxx.ParamPefix, s.rootMaxx.DefaultMatchClause)
+	pattern := ""
+	variables := make([]string, 0, len(elements))
+	conditions := []string{}
+	sets := []string{}
+	actions := ""
+	removes := []string{}
+	nodes, edges := 0, 0
+
+	if len(elements)%2 == 0 {
+		maxx.setErr(ErrInvalidPath)
+	}
+
+	for i, element := range elements {
+		variable := fmt.Sprintf(`n%d`, nodes)
+		if element.IsEdge {
+			variable = fmt.Sprintf(`r%d`, edges)
+			edges++
+		} else {
+			nodes++
+		}
+
+		if element.IsEdge != (i%2 == 1) {
+			maxx.setErr(ErrInvalidPath)
+		}
+
+		part := s.rootMaxx.derive(variable, variable+"_", s.rootMaxx.DefaultMatchClause)
+		if element.Entity != nil {
+			part = part.Parse(element.Entity)
+		}
+
+		labels := part.labels(element.Label)
+		if element.IsEdge {
+			labels = part.label(element.Label)
+		}
+
+		properties := ""
+
+		if element.Entity != nil {
+			switch keyword {
+			case KeywordCreate:
+				if part.mapped() {
+					sets = append(sets, part.mapClause(part.GetTag(PropsParam), "=", false))
+				} else {
+					properties = part.CreateQuery
+				}
+
+			case KeywordMatch:
+				keys := part.pathKeys()
+
+				switch {
+				case element.MatchClause != nil:
+					part.ParseMatchClause(element.MatchClause)
+					part.checkMatchClause(element.MatchClause)
+					conditions = append(conditions, part.MatchClause)
+				case len(keys) > 0:
+					properties, _ = part.mergeKeys(keys)
+				default:
+					part.ParseMatchClause(part.DefaultMatchClause)
+					part.checkMatchClause(part.DefaultMatchClause)
+					conditions = append(conditions, part.MatchClause)
+				}
+
+			case KeywordMerge:
+				keys := part.pathKeys()
+
+				// edges without keys are merged on their type alone
+				if len(keys) > 0 || !element.IsEdge {
+					properties, keys = part.mergeKeys(keys)
+				}
+
+				onSet, remove := part.mergeActions(keys)
+				actions += onSet
+
+				if remove != "" {
+					removes = append(removes, remove)
+				}
+			}
+		}
+
+		// CREATE's properties are already wrapped, the keys are not
+		if properties != "" && keyword != KeywordCreate {
+			properties = fmt.Sprintf(`{%s}`, properties)
+		}
+
+		if properties != "" {
+			properties = " " + properties
+		}
+
+		if element.IsEdge {
+			dirStart, dirEnd := s.getDirection(element.Direction)
+			pattern += fmt.Sprintf(`%s[%s:%s%s]%s`, dirStart, variable, labels, properties, dirEnd)
+		} else {
+			pattern += fmt.Sprintf(`(%s:%s%s)`, variable, labels, properties)
+		}
+
+		maxx.setErr(part.Err)
+		maxx.MergeParams(part.Params)
+		variables = append(variables, variable)
+
+		if keyword == KeywordMatch {
+			conditions = append(conditions, maxx.notDeleted(variable))
+		}
+	}
+
+	maxx.Query = fmt.Sprintf(`%s %s%s`, keyword, pattern, actions)
+
+	if where := joinConditions(conditions...); where != "" {
+		maxx.Query = fmt.Sprintf(`%s WHERE %s`, maxx.Query, where)
+	}
+
+	if len(sets) > 0 {
+		maxx.Query = fmt.Sprintf(`%s SET %s`, maxx.Query, strings.Join(sets, ", "))
+	}
+
+	if len(removes) > 0 {
+		maxx.Query = fmt.Sprintf(`%s REMOVE %s`, maxx.Query, strings.Join(removes, ", "))
+	}
+
+	if withReturn {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, strings.Join(variables, ", "))
+	}
+
+	maxx.trimParams()
+
+	return maxx
+}
+
+// TraversalMode defines which paths a traversal matches
+type TraversalMode int
+
+const (
+	// TraverseAll matches every path
+	TraverseAll TraversalMode = iota
+
+	// TraverseShortest matches a single shortest path with shortestPath
+	TraverseShortest
+
+	// TraverseAllShortest matches every shortest path with allShortestPaths
+	TraverseAllShortest
+)
+
+// Traversal describes the relationships that are followed from a start node
+type Traversal struct {
+	// the edge types, any type is followed when it is empty
+	Types []string
+
+	// "in", "out", or both ways when it is anything else
+	Direction string
+
+	// the minimum and maximum number of hops, nil leaves the bound out:
+	// 1 and 3 is *1..3, 0 and 3 is *0..3, nil and 3 is *..3, and nil and nil is *.
+	// Use Hops to set them
+	MinHops *int
+	MaxHops *int
+
+	// the label of the nodes found at the end of the paths, optional
+	EndLabel *string
+
+	// several labels for the nodes found at the end of the paths, they are
+	// used in place of EndLabel
+	EndLabels []string
+
+	Mode TraversalMode
+}
+
+// Hops returns a pointer to the number of hops for Traversal.MinHops and Traversal.MaxHops
+func Hops(hops int) *int {
+	return &hops
+}
+
+// hops returns the variable length part of the relationship pattern. Negative
+// hops, a minimum above the maximum, and shortest paths that don't start at 0
+// or 1 hop are an ErrInvalidHops
+func (t Traversal) hops() (string, error) {
+	min, max := t.MinHops, t.MaxHops
+
+	switch {
+	case (min != nil && *min < 0) || (max != nil && *max < 0):
+		return "*", fmt.Errorf(`%w: hops can't be negative`, ErrInvalidHops)
+	case min != nil && max != nil && *min > *max:
+		return "*", fmt.Errorf(`%w: the minimum %d is more than the maximum %d`, ErrInvalidHops, *min, *max)
+	case min != nil && *min > 1 && t.Mode != TraverseAll:
+		return "*", fmt.Errorf(`%w: shortest paths start at 0 or 1 hop, not %d`, ErrInvalidHops, *min)
+	}
+
+	switch {
+	case min == nil && max == nil:
+		return "*", nil
+	case min == nil:
+		return fmt.Sprintf(`*..%d`, *max), nil
+	case max == nil:
+		return fmt.Sprintf(`*%d..`, *min), nil
+	case *min == *max:
+		return fmt.Sprintf(`*%d`, *min), nil
+	}
+
+	return fmt.Sprintf(`*%d..%d`, *min, *max), nil
+}
+
+// MATCH (start:Label) WHERE id(start) = $start_id MATCH (start)-[:T1|T2*1..3]->(end:Label) RETURN end
+// MATCH (start:Label) WHERE id(start) = $start_id MATCH path = shortestPath((start)-[:T*..3]->(end:Label)) RETURN path
+func (s *synclarie) traverse(start interface{}, startLabel *string, startMatchClause Clause, traversal Traversal, withReturn bool) *Maxine {
+	startRoot := s.rootMaxx.derive(s.startVariable, s.startVariable+"_", startMatchClause)
+	maxx := newRegine(startMatchClause, startRoot).matchNode(start, startLabel, false)
+	dirStart, dirEnd := s.getDirection(traversal.Direction)
+	edgeTypes := ""
+	endLabel := ""
+
+	if len(traversal.Types) > 0 {
+		edgeTypes = ":" + maxx.joinTypes(traversal.Types)
+	}
+
+	switch {
+	case len(traversal.EndLabels) > 0:
+		endLabel = ":" + maxx.joinLabels(traversal.EndLabels)
+	case traversal.EndLabel != nil:
+		endLabel = ":" + maxx.label(traversal.EndLabel)
+	}
+
+	hops, err := traversal.hops()
+	maxx.setErr(err)
+
+	pattern := fmt.Sprintf(`(%s)%s[%s%s]%s(%s%s)`, s.startVariable, dirStart, edgeTypes, hops, dirEnd, s.endVariable, endLabel)
+	returned := s.endVariable
+
+	// every node and edge along the way is checked for soft deletes, which
+	// needs the path
+	filter := maxx.notDeleted("n")
+	if filter != "" {
+		filter = fmt.Sprintf(`all(n IN nodes(%s) WHERE %s) AND all(r IN relationships(%s) WHERE %s)`, PathVariable, filter, PathVariable, maxx.notDeleted("r"))
+	}
+
+	switch traversal.Mode {
+	case TraverseShortest:
+		pattern = fmt.Sprintf(`%s = shortestPath(%s)`, PathVariable, pattern)
+		returned = PathVariable
+	case TraverseAllShortest:
+		pattern = fmt.Sprintf(`%s = allShortestPaths(%s)`, PathVariable, pattern)
+		returned = PathVariable
+	default:
+		if filter != "" {
+			pattern = fmt.Sprintf(`%s = %s`, PathVariable, pattern)
+		}
+	}
+
+	maxx.Query = fmt.Sprintf(`%s MATCH %s`, maxx.Query, pattern)
+
+	if filter != "" {
+		maxx.Query = fmt.Sprintf(`%s WHERE %s`, maxx.Query, filter)
+	}
+
+	if withReturn {
+		maxx.Query = fmt.Sprintf(`%s RETURN %s`, maxx.Query, returned)
+	}
 
 	return maxx
 }